@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/MMC-BK/lw-api/auth/models"
+	"github.com/MMC-BK/lw-api/redact"
 	"github.com/go-openapi/strfmt"
 	"io"
 	"net/http"
@@ -53,35 +54,143 @@ type TokenSource interface {
 	ForceRefresh(ctx context.Context) error         // принудительно обновить (на 401, например)
 }
 
-// Реализация через refresh-эндпоинт
+// StaleTokenRefresher — TokenSource, который умеет не обновлять токен повторно,
+// если отказавший токен уже заменён (например, соседней горутиной).
+type StaleTokenRefresher interface {
+	RefreshStale(ctx context.Context, stale strfmt.UUID) error
+}
+
+// Реализация через refresh-эндпоинт.
+// Конкурентные refresh схлопываются в один запрос к AuthorizeByApplication.
 type RefreshTokenSource struct {
 	creds   Credentials
 	baseURL *url.URL
 	hc      *http.Client
+	store   TokenStore
+
+	mu       sync.Mutex
+	token    strfmt.UUID
+	exp      time.Time
+	server   string
+	inflight *refreshCall
+
+	// фоновый refresh (WithProactiveRefresh)
+	lead      time.Duration
+	changed   chan struct{}
+	closed    chan struct{}
+	bgDone    chan struct{}
+	closeOnce sync.Once
+}
+
+type refreshCall struct {
+	done chan struct{}
+	err  error
+}
+
+const DefaultAuthURL = "https://api.linnworks.net"
+
+// токен считается протухшим за expirySkew до фактического истечения
+const expirySkew = 30 * time.Second
+
+// пауза перед повтором неудачного фонового refresh
+const backgroundRetryDelay = 5 * time.Second
+
+// refreshTimeout ограничивает refresh, отвязанный от контекста вызывающего,
+// вместе с ожиданием блокировки хранилища.
+var refreshTimeout = 30 * time.Second
+
+// minProactiveInterval — минимальная пауза между фоновыми refresh, даже если токен живёт меньше.
+var minProactiveInterval = time.Second
 
-	mu    sync.Mutex
-	token strfmt.UUID
-	exp   time.Time
+type Option func(*RefreshTokenSource) error
+
+// WithAuthURL меняет хост эндпоинта AuthorizeByApplication (например, на httptest-сервер).
+func WithAuthURL(raw string) Option {
+	return func(r *RefreshTokenSource) error {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return err
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("auth url %q must be absolute", raw)
+		}
+		r.baseURL = u
+		return nil
+	}
+}
+
+func WithHTTPClient(hc *http.Client) Option {
+	return func(r *RefreshTokenSource) error {
+		if hc == nil {
+			return errors.New("http client is nil")
+		}
+		r.hc = hc
+		return nil
+	}
+}
+
+// WithTokenStore подключает хранилище: токен читается из него до похода в API и пишется после.
+func WithTokenStore(store TokenStore) Option {
+	return func(r *RefreshTokenSource) error {
+		if store == nil {
+			return errors.New("token store is nil")
+		}
+		r.store = store
+		return nil
+	}
+}
+
+// WithProactiveRefresh запускает фоновую горутину, которая обновляет токен за lead до истечения TTL.
+// lead больше половины оставшегося срока токена урезается до половины. Горутину останавливает Close.
+func WithProactiveRefresh(lead time.Duration) Option {
+	return func(r *RefreshTokenSource) error {
+		if lead <= 0 {
+			return errors.New("proactive refresh lead must be greater than 0")
+		}
+		r.lead = lead
+		return nil
+	}
 }
 
-// Конструктор
+// NewRefreshTokenSource — прежний конструктор без опций.
+//
+// Deprecated: используйте NewTokenSource: он принимает опции (WithAuthURL, WithProactiveRefresh…)
+// и возвращает ошибку для некорректных.
 func NewRefreshTokenSource(creds Credentials) *RefreshTokenSource {
+	r, _ := NewTokenSource(creds)
+	return r
+}
+
+// NewTokenSource создаёт RefreshTokenSource с опциями.
+func NewTokenSource(creds Credentials, opts ...Option) (*RefreshTokenSource, error) {
 	hc := &http.Client{Timeout: 30 * time.Second}
-	parsedAuthURL, _ := url.Parse("https://api.linnworks.net")
-	return &RefreshTokenSource{hc: hc, baseURL: parsedAuthURL, creds: creds}
+	parsedAuthURL, _ := url.Parse(DefaultAuthURL)
+	r := &RefreshTokenSource{hc: hc, baseURL: parsedAuthURL, creds: creds}
+	for _, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, err
+		}
+	}
+	if r.lead > 0 {
+		r.changed = make(chan struct{}, 1)
+		r.closed = make(chan struct{})
+		r.bgDone = make(chan struct{})
+		go r.backgroundLoop()
+	}
+	return r, nil
 }
 
 func (r *RefreshTokenSource) Token(ctx context.Context) (strfmt.UUID, error) {
 	r.mu.Lock()
 	// если токен ещё валиден с запасом — отдать из кеша
-	if r.token != "" && time.Until(r.exp) > 30*time.Second {
+	if r.validLocked() {
 		t := r.token
 		r.mu.Unlock()
 		return t, nil
 	}
 	r.mu.Unlock()
-	// иначе — обновить
-	if err := r.refresh(ctx); err != nil {
+	// иначе — обновить (или дождаться уже идущего обновления)
+	if err := r.refresh(ctx, false, ""); err != nil {
 		return "", err
 	}
 	r.mu.Lock()
@@ -89,14 +198,199 @@ func (r *RefreshTokenSource) Token(ctx context.Context) (strfmt.UUID, error) {
 	return r.token, nil
 }
 
-func (r *RefreshTokenSource) ForceRefresh(ctx context.Context) error {
-	return r.refresh(ctx)
+// ServerSource отдаёт API-хост текущей сессии (поле Server из AuthorizeByApplication).
+type ServerSource interface {
+	Server(ctx context.Context) (string, error)
 }
 
-func (r *RefreshTokenSource) refresh(ctx context.Context) error {
+// Server возвращает хост из последней сессии, при необходимости получая токен.
+func (r *RefreshTokenSource) Server(ctx context.Context) (string, error) {
+	if _, err := r.Token(ctx); err != nil {
+		return "", err
+	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if r.server == "" {
+		return "", errors.New("session did not return a server")
+	}
+	return r.server, nil
+}
+
+// ForceRefresh обновляет токен; если обновление уже идёт — присоединяется к нему.
+func (r *RefreshTokenSource) ForceRefresh(ctx context.Context) error {
+	r.mu.Lock()
+	current := r.token
+	r.mu.Unlock()
+	return r.refresh(ctx, true, current)
+}
+
+// RefreshStale обновляет токен, только если stale всё ещё текущий.
+func (r *RefreshTokenSource) RefreshStale(ctx context.Context, stale strfmt.UUID) error {
+	return r.refresh(ctx, true, stale)
+}
+
+// Close останавливает фоновый refresh. Повторный вызов безопасен.
+func (r *RefreshTokenSource) Close() error {
+	if r.closed == nil {
+		return nil
+	}
+	r.closeOnce.Do(func() { close(r.closed) })
+	<-r.bgDone
+	return nil
+}
+
+func (r *RefreshTokenSource) validLocked() bool {
+	return r.token != "" && time.Until(r.exp) > expirySkew
+}
+
+// refresh запускает обновление или присоединяется к идущему. При force токен stale
+// считается протухшим: если он уже заменён валидным, повторный refresh не нужен.
+func (r *RefreshTokenSource) refresh(ctx context.Context, force bool, stale strfmt.UUID) error {
+	r.mu.Lock()
+	if call := r.inflight; call != nil {
+		r.mu.Unlock()
+		return call.wait(ctx)
+	}
+	if r.validLocked() && (!force || r.token != stale) {
+		r.mu.Unlock()
+		return nil
+	}
+	call := &refreshCall{done: make(chan struct{})}
+	r.inflight = call
+	r.mu.Unlock()
+
+	// запрос не привязан к отмене вызывающего: его результат ждут и другие горутины
+	go func() {
+		octx, cancel := context.WithTimeout(context.WithoutCancel(ctx), refreshTimeout)
+		st, err := r.obtain(octx, force, stale)
+		cancel()
+		r.mu.Lock()
+		if err == nil {
+			r.token, r.exp, r.server = st.Token, st.Expiry, st.Server
+			r.notifyChanged()
+		}
+		call.err = err
+		r.inflight = nil
+		r.mu.Unlock()
+		close(call.done)
+	}()
+	return call.wait(ctx)
+}
+
+func (c *refreshCall) wait(ctx context.Context) error {
+	select {
+	case <-c.done:
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// obtain берёт токен из хранилища, если там есть подходящий, иначе идёт в AuthorizeByApplication.
+func (r *RefreshTokenSource) obtain(ctx context.Context, force bool, stale strfmt.UUID) (StoredToken, error) {
+	if r.store == nil {
+		return r.authorize(ctx)
+	}
+	if st, ok := r.loadStored(ctx, force, stale); ok {
+		return st, nil
+	}
+	if locker, ok := r.store.(TokenLocker); ok {
+		unlock, err := locker.Lock(ctx)
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("%w: %w", ErrLockTimeout, err)
+		}
+		if err != nil {
+			return StoredToken{}, fmt.Errorf("lock token store: %w", err)
+		}
+		defer unlock()
+		// пока ждали блокировку, соседний процесс мог уже обновить токен
+		if st, ok := r.loadStored(ctx, force, stale); ok {
+			return st, nil
+		}
+	}
+	st, err := r.authorize(ctx)
+	if err != nil {
+		return StoredToken{}, err
+	}
+	if err := r.store.Save(ctx, st); err != nil {
+		return StoredToken{}, fmt.Errorf("save token: %w", err)
+	}
+	return st, nil
+}
+
+// loadStored: ошибки чтения считаются промахом — битый файл не должен блокировать авторизацию.
+func (r *RefreshTokenSource) loadStored(ctx context.Context, force bool, stale strfmt.UUID) (StoredToken, bool) {
+	st, err := r.store.Load(ctx)
+	if err != nil || st == nil || st.Token == "" || time.Until(st.Expiry) <= expirySkew {
+		return StoredToken{}, false
+	}
+	if force && st.Token == stale {
+		return StoredToken{}, false
+	}
+	return *st, true
+}
+
+func (r *RefreshTokenSource) notifyChanged() {
+	if r.changed == nil {
+		return
+	}
+	select {
+	case r.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (r *RefreshTokenSource) backgroundLoop() {
+	defer close(r.bgDone)
+	for {
+		r.mu.Lock()
+		token, exp := r.token, r.exp
+		r.mu.Unlock()
+
+		// пока токена нет, ждём первого обычного refresh
+		var timer *time.Timer
+		var fire <-chan time.Time
+		if token != "" {
+			timer = time.NewTimer(proactiveDelay(time.Until(exp), r.lead))
+			fire = timer.C
+		}
+		select {
+		case <-r.closed:
+			stopTimer(timer)
+			return
+		case <-r.changed:
+			stopTimer(timer)
+			continue
+		case <-fire:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		err := r.refresh(ctx, true, token)
+		cancel()
+		if err != nil {
+			select {
+			case <-r.closed:
+				return
+			case <-time.After(backgroundRetryDelay):
+			}
+		}
+	}
+}
 
+// proactiveDelay — через сколько обновить токен, которому осталось жить remaining. lead не больше
+// половины remaining: иначе при lead >= TTL каждый новый токен сразу уходил бы на refresh.
+func proactiveDelay(remaining, lead time.Duration) time.Duration {
+	lead = min(lead, remaining/2)
+	return max(remaining-lead, minProactiveInterval)
+}
+
+func stopTimer(t *time.Timer) {
+	if t != nil {
+		t.Stop()
+	}
+}
+
+func (r *RefreshTokenSource) authorize(ctx context.Context) (StoredToken, error) {
 	// Собираем URL: /auth/refresh — поменяй на свой путь
 	u := r.baseURL.ResolveReference(&url.URL{Path: "/api/Auth/AuthorizeByApplication"})
 	body := map[string]string{
@@ -108,35 +402,44 @@ func (r *RefreshTokenSource) refresh(ctx context.Context) error {
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(b))
 	if err != nil {
-		return err
+		return StoredToken{}, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := r.hc.Do(req)
 	if err != nil {
-		return err
+		return StoredToken{}, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		data, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("refresh failed: %d %s", resp.StatusCode, strings.TrimSpace(string(data)))
+		// тело ответа может содержать секреты из запроса — в ошибку только через redact
+		return StoredToken{}, fmt.Errorf("refresh failed: %d %s", resp.StatusCode, redact.Body(data))
 	}
 
 	var rr models.BaseSession
 	if err := json.NewDecoder(resp.Body).Decode(&rr); err != nil {
-		return err
+		return StoredToken{}, err
 	}
 	if rr.Token == "" {
-		return errors.New("refresh returned empty access token")
+		return StoredToken{}, errors.New("refresh returned empty access token")
 	}
-	r.token = rr.Token
+	st := StoredToken{Token: rr.Token, Server: normalizeServer(rr.Server)}
 	// Если API отдаёт ExpiresIn, рассчитываем exp, иначе — ставим разумный TTL (например, 10 мин)
 	if rr.TTL > 0 {
-		r.exp = time.Now().Add(time.Duration(rr.TTL) * time.Second)
+		st.Expiry = time.Now().Add(time.Duration(rr.TTL) * time.Second)
 	} else {
-		r.exp = time.Now().Add(10 * time.Minute)
+		st.Expiry = time.Now().Add(10 * time.Minute)
 	}
-	return nil
+	return st, nil
+}
+
+func normalizeServer(server string) string {
+	server = strings.TrimRight(strings.TrimSpace(server), "/")
+	if server != "" && !strings.Contains(server, "://") {
+		server = "https://" + server
+	}
+	return server
 }
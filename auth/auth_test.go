@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRefreshTokenSource(t *testing.T) {
+	t.Run("concurrent Token calls should share one refresh", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := newAuthServer(t, &calls)
+		ts, _ := NewTokenSource(Credentials{}, WithAuthURL(srv.URL))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := ts.Token(context.Background()); err != nil {
+					t.Errorf("Expected nil error, got %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+		if calls.Load() != 1 {
+			t.Errorf("Expected 1 authorize call, got %d", calls.Load())
+		}
+	})
+
+	t.Run("deprecated constructor should keep working", func(t *testing.T) {
+		ts := NewRefreshTokenSource(Credentials{})
+		if ts == nil || ts.baseURL.String() != DefaultAuthURL {
+			t.Errorf("unexpected token source %+v", ts)
+		}
+	})
+
+	t.Run("RefreshStale should skip a token that was already replaced", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := newAuthServer(t, &calls)
+		ts, _ := NewTokenSource(Credentials{}, WithAuthURL(srv.URL))
+		stale, _ := ts.Token(context.Background())
+
+		for i := 0; i < 3; i++ {
+			if err := ts.RefreshStale(context.Background(), stale); err != nil {
+				t.Fatalf("Expected nil error, got %v", err)
+			}
+		}
+		if calls.Load() != 2 {
+			t.Errorf("Expected 2 authorize calls, got %d", calls.Load())
+		}
+	})
+
+	t.Run("proactive refresh should renew before expiry and stop on Close", func(t *testing.T) {
+		defer setMinProactiveInterval(10 * time.Millisecond)()
+		var calls atomic.Int32
+		srv := newShortTTLAuthServer(t, &calls)
+		// lead больше TTL урезается до половины оставшегося срока, то есть ~500ms
+		ts, err := NewTokenSource(Credentials{}, WithAuthURL(srv.URL), WithProactiveRefresh(time.Hour))
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if _, err := ts.Token(context.Background()); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		deadline := time.Now().Add(3 * time.Second)
+		for calls.Load() < 3 && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if err := ts.Close(); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if calls.Load() < 3 {
+			t.Errorf("Expected background refreshes, got %d calls", calls.Load())
+		}
+		after := calls.Load()
+		time.Sleep(50 * time.Millisecond)
+		if calls.Load() != after {
+			t.Errorf("Expected no refreshes after Close, got %d more", calls.Load()-after)
+		}
+	})
+
+	t.Run("proactive refresh should not flood when lead exceeds the TTL", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := newAuthServer(t, &calls)
+		ts, err := NewTokenSource(Credentials{}, WithAuthURL(srv.URL), WithProactiveRefresh(time.Hour))
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		defer ts.Close()
+		if _, err := ts.Token(context.Background()); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		time.Sleep(200 * time.Millisecond)
+		if got := calls.Load(); got != 1 {
+			t.Errorf("Expected 1 authorize call, got %d", got)
+		}
+	})
+
+	t.Run("proactive refresh delay should be bounded", func(t *testing.T) {
+		cases := []struct{ remaining, lead, want time.Duration }{
+			{30 * time.Minute, 5 * time.Minute, 25 * time.Minute},
+			{30 * time.Minute, time.Hour, 15 * time.Minute},
+			{0, time.Minute, minProactiveInterval},
+			{-time.Minute, time.Minute, minProactiveInterval},
+		}
+		for _, c := range cases {
+			if got := proactiveDelay(c.remaining, c.lead); got != c.want {
+				t.Errorf("proactiveDelay(%s, %s): expected %s, got %s", c.remaining, c.lead, c.want, got)
+			}
+		}
+	})
+}
+
+func setMinProactiveInterval(d time.Duration) (restore func()) {
+	prev := minProactiveInterval
+	minProactiveInterval = d
+	return func() { minProactiveInterval = prev }
+}
+
+func setRefreshTimeout(d time.Duration) (restore func()) {
+	prev := refreshTimeout
+	refreshTimeout = d
+	return func() { refreshTimeout = prev }
+}
+
+// newShortTTLAuthServer выдаёт токены на одну секунду.
+func newShortTTLAuthServer(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		fmt.Fprintf(w, `{"Token":"00000000-0000-0000-0000-%012d","TTL":1}`, n)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package auth
+
+import "os"
+
+// На платформах без flock блокировка между процессами не поддерживается,
+// внутри процесса refresh всё равно сериализован мьютексом RefreshTokenSource.
+func tryLockFile(*os.File) (bool, error) { return true, nil }
+
+func unlockFile(*os.File) error { return nil }
@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+)
+
+// ========== TokenStore: хранение сессии между перезапусками ==========
+
+// StoredToken — то, что сохраняется в TokenStore.
+type StoredToken struct {
+	Token  strfmt.UUID `json:"token"`
+	Expiry time.Time   `json:"expiry"`
+	Server string      `json:"server,omitempty"`
+}
+
+// TokenStore хранит сессионный токен. Load возвращает (nil, nil), если токена нет.
+type TokenStore interface {
+	Load(ctx context.Context) (*StoredToken, error)
+	Save(ctx context.Context, token StoredToken) error
+}
+
+// TokenLocker — опциональная межпроцессная блокировка, которую RefreshTokenSource
+// берёт на время refresh, чтобы на одном хосте за токеном сходил только один процесс.
+type TokenLocker interface {
+	Lock(ctx context.Context) (unlock func() error, err error)
+}
+
+// ErrLockTimeout — блокировку хранилища не удалось взять за время refresh.
+var ErrLockTimeout = errors.New("token store lock timed out")
+
+// ---------- память ----------
+
+type MemoryTokenStore struct {
+	mu    sync.Mutex
+	token *StoredToken
+}
+
+func NewMemoryTokenStore() *MemoryTokenStore { return &MemoryTokenStore{} }
+
+func (m *MemoryTokenStore) Load(context.Context) (*StoredToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.token == nil {
+		return nil, nil
+	}
+	t := *m.token
+	return &t, nil
+}
+
+func (m *MemoryTokenStore) Save(_ context.Context, token StoredToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.token = &token
+	return nil
+}
+
+// ---------- файл ----------
+
+// FileTokenStore хранит токен в JSON-файле (0600) и блокирует соседний .lock файл.
+type FileTokenStore struct {
+	path   string
+	encode func([]byte) ([]byte, error)
+	decode func([]byte) ([]byte, error)
+}
+
+func NewFileTokenStore(path string) (*FileTokenStore, error) {
+	if path == "" {
+		return nil, errors.New("token store path is required")
+	}
+	identity := func(b []byte) ([]byte, error) { return b, nil }
+	return &FileTokenStore{path: path, encode: identity, decode: identity}, nil
+}
+
+// NewEncryptedFileTokenStore шифрует файл AES-GCM; key — 16, 24 или 32 байта.
+func NewEncryptedFileTokenStore(path string, key []byte) (*FileTokenStore, error) {
+	s, err := NewFileTokenStore(path)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("token store key: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	s.encode = func(plain []byte) ([]byte, error) {
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, err
+		}
+		return gcm.Seal(nonce, nonce, plain, nil), nil
+	}
+	s.decode = func(data []byte) ([]byte, error) {
+		if len(data) < gcm.NonceSize() {
+			return nil, errors.New("token store: ciphertext too short")
+		}
+		nonce, sealed := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+		return gcm.Open(nil, nonce, sealed, nil)
+	}
+	return s, nil
+}
+
+func (s *FileTokenStore) Load(context.Context) (*StoredToken, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	plain, err := s.decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("token store %s: %w", s.path, err)
+	}
+	var t StoredToken
+	if err := json.Unmarshal(plain, &t); err != nil {
+		return nil, fmt.Errorf("token store %s: %w", s.path, err)
+	}
+	return &t, nil
+}
+
+func (s *FileTokenStore) Save(_ context.Context, token StoredToken) error {
+	plain, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	data, err := s.encode(plain)
+	if err != nil {
+		return err
+	}
+	// пишем во временный файл и переименовываем, чтобы читатели не увидели половину файла
+	dir := filepath.Dir(s.path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func (s *FileTokenStore) Lock(ctx context.Context) (func() error, error) {
+	f, err := os.OpenFile(s.path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		ok, err := tryLockFile(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		if ok {
+			return func() error {
+				uerr := unlockFile(f)
+				return errors.Join(uerr, f.Close())
+			}, nil
+		}
+		select {
+		case <-ctx.Done():
+			f.Close()
+			return nil, ctx.Err()
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newAuthServer(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		fmt.Fprintf(w, `{"Token":"00000000-0000-0000-0000-%012d","TTL":1800,"Server":"https://eu-ext.linnworks.net"}`, n)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFileTokenStore(t *testing.T) {
+	t.Run("sources sharing a file store should authorize once", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := newAuthServer(t, &calls)
+		path := filepath.Join(t.TempDir(), "token.json")
+
+		for i := 0; i < 3; i++ {
+			store, err := NewFileTokenStore(path)
+			if err != nil {
+				t.Fatalf("Expected nil error, got %v", err)
+			}
+			ts, err := NewTokenSource(Credentials{}, WithAuthURL(srv.URL), WithTokenStore(store))
+			if err != nil {
+				t.Fatalf("Expected nil error, got %v", err)
+			}
+			if _, err := ts.Token(context.Background()); err != nil {
+				t.Fatalf("Expected nil error, got %v", err)
+			}
+			server, _ := ts.Server(context.Background())
+			if server != "https://eu-ext.linnworks.net" {
+				t.Errorf("Expected server to be restored, got %q", server)
+			}
+		}
+		if calls.Load() != 1 {
+			t.Errorf("Expected 1 authorize call, got %d", calls.Load())
+		}
+	})
+
+	t.Run("force refresh should not reuse the stale stored token", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := newAuthServer(t, &calls)
+		ts, _ := NewTokenSource(Credentials{}, WithAuthURL(srv.URL), WithTokenStore(NewMemoryTokenStore()))
+		first, _ := ts.Token(context.Background())
+		if err := ts.ForceRefresh(context.Background()); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		second, _ := ts.Token(context.Background())
+		if first == second {
+			t.Errorf("Expected a new token after force refresh, got %s twice", first)
+		}
+	})
+
+	t.Run("refresh should give up on a lock held by another process", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("file lock is a no-op on windows")
+		}
+		defer setRefreshTimeout(100 * time.Millisecond)()
+		var calls atomic.Int32
+		srv := newAuthServer(t, &calls)
+		path := filepath.Join(t.TempDir(), "token.json")
+
+		holder, _ := NewFileTokenStore(path)
+		unlock, err := holder.Lock(context.Background())
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		defer unlock()
+
+		store, _ := NewFileTokenStore(path)
+		ts, _ := NewTokenSource(Credentials{}, WithAuthURL(srv.URL), WithTokenStore(store))
+		if _, err := ts.Token(context.Background()); !errors.Is(err, ErrLockTimeout) {
+			t.Errorf("Expected ErrLockTimeout, got %v", err)
+		}
+		if calls.Load() != 0 {
+			t.Errorf("Expected no authorize calls, got %d", calls.Load())
+		}
+	})
+
+	t.Run("encrypted store should reject a wrong key", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "token.bin")
+		store, err := NewEncryptedFileTokenStore(path, make([]byte, 32))
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		want := StoredToken{Token: "00000000-0000-0000-0000-000000000001", Expiry: time.Now().Add(time.Hour).UTC()}
+		if err := store.Save(context.Background(), want); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		got, err := store.Load(context.Background())
+		if err != nil || got.Token != want.Token || !got.Expiry.Equal(want.Expiry) {
+			t.Errorf("Expected %+v, got %+v (%v)", want, got, err)
+		}
+
+		key := make([]byte, 32)
+		key[0] = 1
+		other, _ := NewEncryptedFileTokenStore(path, key)
+		if _, err := other.Load(context.Background()); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/MMC-BK/lw-api/auth"
+	"github.com/go-openapi/strfmt"
+)
+
+// AuthCounters — счётчики AuthRefreshMiddleware.
+type AuthCounters struct {
+	refreshes atomic.Int64
+	resigned  atomic.Int64
+}
+
+// Refreshes — сколько раз 401 привёл к запросу на refresh токена
+// (TokenSource может схлопнуть их в меньшее число обращений к AuthorizeByApplication).
+func (c *AuthCounters) Refreshes() int64 { return c.refreshes.Load() }
+
+// ResignedRetries — сколько запросов было повторено с новым токеном.
+func (c *AuthCounters) ResignedRetries() int64 { return c.resigned.Load() }
+
+// AuthRefreshMiddleware подписывает запрос, а на 401 обновляет токен и повторяет запрос
+// один раз с новым заголовком Authorization. Если хост берётся из сессии (WithBaseURLSource),
+// повтор уходит на хост, выданный вместе с новым токеном. counters может быть nil.
+func AuthRefreshMiddleware(ts auth.TokenSource, counters *AuthCounters) RTMiddleware {
+	if counters == nil {
+		counters = &AuthCounters{}
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			// тело запроса может потребоваться повторно прочитать
+			var bodyCopy []byte
+			if req.Body != nil {
+				var err error
+				bodyCopy, err = io.ReadAll(req.Body)
+				req.Body.Close()
+				if err != nil {
+					return nil, err
+				}
+			}
+			ctx := req.Context()
+
+			token, err := ts.Token(ctx)
+			if err != nil {
+				return nil, err
+			}
+			// повтор из RetryPolicyMiddleware несёт исходный URL, а сессия могла уже переехать
+			req, err = rehosted(req)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := next.RoundTrip(signed(req, token, bodyCopy))
+			if err != nil || resp.StatusCode != http.StatusUnauthorized {
+				return resp, err
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+
+			counters.refreshes.Add(1)
+			if err := refreshStale(ctx, ts, token); err != nil {
+				return nil, fmt.Errorf("401 and refresh failed: %w", err)
+			}
+			fresh, err := ts.Token(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("401 and refresh failed: %w", err)
+			}
+			if req, err = rehosted(req); err != nil {
+				return nil, fmt.Errorf("401 and refresh failed: %w", err)
+			}
+			counters.resigned.Add(1)
+			return next.RoundTrip(signed(req, fresh, bodyCopy))
+		})
+	}
+}
+
+// signed возвращает копию запроса с заголовком Authorization: исходный запрос
+// по контракту RoundTripper менять нельзя.
+func signed(req *http.Request, token strfmt.UUID, body []byte) *http.Request {
+	out := req.Clone(req.Context())
+	out.Header.Set("Authorization", token.String())
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+		out.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+		out.ContentLength = int64(len(body))
+	}
+	return out
+}
+
+// refreshStale обновляет токен; если stale уже заменён другой горутиной, повторный refresh не нужен.
+func refreshStale(ctx context.Context, ts auth.TokenSource, stale strfmt.UUID) error {
+	if sr, ok := ts.(auth.StaleTokenRefresher); ok {
+		return sr.RefreshStale(ctx, stale)
+	}
+	return ts.ForceRefresh(ctx)
+}
@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/MMC-BK/lw-api/auth"
+)
+
+// rotatingServer выдаёт новый токен на каждый AuthorizeByApplication и принимает только последний.
+type rotatingServer struct {
+	mu         sync.Mutex
+	issued     int
+	valid      string
+	authorizes atomic.Int32
+}
+
+func (s *rotatingServer) revoke() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.valid = ""
+}
+
+func (s *rotatingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.URL.Path == "/api/Auth/AuthorizeByApplication" {
+		s.authorizes.Add(1)
+		s.issued++
+		s.valid = fmt.Sprintf("00000000-0000-0000-0000-%012d", s.issued)
+		fmt.Fprintf(w, `{"Token":%q,"TTL":1800}`, s.valid)
+		return
+	}
+	if r.Header.Get("Authorization") != s.valid || s.valid == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	w.Write(body)
+}
+
+func newAuthTestClient(t *testing.T) (*Client, *rotatingServer, *AuthCounters) {
+	t.Helper()
+	rs := &rotatingServer{}
+	srv := httptest.NewServer(rs)
+	t.Cleanup(srv.Close)
+	ts, err := auth.NewTokenSource(auth.Credentials{}, auth.WithAuthURL(srv.URL))
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	counters := &AuthCounters{}
+	c, err := NewClient(WithBaseURL(srv.URL), WithTransportChain(AuthRefreshMiddleware(ts, counters)))
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	return c, rs, counters
+}
+
+func TestAuthRefreshMiddleware(t *testing.T) {
+	t.Run("should re-sign the retried request with the refreshed token", func(t *testing.T) {
+		c, rs, counters := newAuthTestClient(t)
+		ctx := context.Background()
+
+		var out map[string]int
+		if err := c.DoJSON(ctx, http.MethodPost, "/api/Orders/GetOrdersById", nil, map[string]int{"n": 1}, &out); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		rs.revoke()
+		if err := c.DoJSON(ctx, http.MethodPost, "/api/Orders/GetOrdersById", nil, map[string]int{"n": 2}, &out); err != nil {
+			t.Fatalf("Expected nil error after refresh, got %v", err)
+		}
+		if out["n"] != 2 {
+			t.Errorf("Expected request body to be replayed, got %v", out)
+		}
+		if counters.Refreshes() != 1 || counters.ResignedRetries() != 1 {
+			t.Errorf("Expected 1 refresh and 1 re-signed retry, got %d and %d", counters.Refreshes(), counters.ResignedRetries())
+		}
+		if rs.authorizes.Load() != 2 {
+			t.Errorf("Expected 2 authorize calls, got %d", rs.authorizes.Load())
+		}
+	})
+
+	t.Run("concurrent 401s should trigger a single authorize call", func(t *testing.T) {
+		c, rs, counters := newAuthTestClient(t)
+		ctx := context.Background()
+		if err := c.DoJSON(ctx, http.MethodGet, "/api/Inventory/GetStockLocations", nil, nil, nil); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		rs.revoke()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := c.DoJSON(ctx, http.MethodGet, "/api/Inventory/GetStockLocations", nil, nil, nil); err != nil {
+					t.Errorf("Expected nil error, got %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+		if rs.authorizes.Load() != 2 {
+			t.Errorf("Expected 2 authorize calls, got %d", rs.authorizes.Load())
+		}
+		if counters.ResignedRetries() != counters.Refreshes() {
+			t.Errorf("Expected every refresh to be followed by a re-signed retry, got %d/%d", counters.Refreshes(), counters.ResignedRetries())
+		}
+	})
+
+	t.Run("should return 401 when the refreshed token is rejected too", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/Auth/AuthorizeByApplication" {
+				fmt.Fprint(w, `{"Token":"00000000-0000-0000-0000-000000000001","TTL":1800}`)
+				return
+			}
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer srv.Close()
+		ts, _ := auth.NewTokenSource(auth.Credentials{}, auth.WithAuthURL(srv.URL))
+		c, _ := NewClient(WithBaseURL(srv.URL), WithTransportChain(AuthRefreshMiddleware(ts, nil)))
+
+		err := c.DoJSON(context.Background(), http.MethodGet, "/api/Inventory/GetStockLocations", nil, nil, nil)
+		if !IsUnauthorized(err) {
+			t.Errorf("Expected unauthorized error, got %v", err)
+		}
+	})
+
+	t.Run("should not refresh on 403", func(t *testing.T) {
+		var authorizes atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/Auth/AuthorizeByApplication" {
+				authorizes.Add(1)
+				fmt.Fprint(w, `{"Token":"00000000-0000-0000-0000-000000000001","TTL":1800}`)
+				return
+			}
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer srv.Close()
+		ts, _ := auth.NewTokenSource(auth.Credentials{}, auth.WithAuthURL(srv.URL))
+		c, _ := NewClient(WithBaseURL(srv.URL), WithTransportChain(AuthRefreshMiddleware(ts, nil)))
+
+		err := c.DoJSON(context.Background(), http.MethodGet, "/api/Inventory/GetStockLocations", nil, nil, nil)
+		if !IsForbidden(err) || IsUnauthorized(err) {
+			t.Errorf("Expected forbidden error only, got %v", err)
+		}
+		if got := authorizes.Load(); got != 1 {
+			t.Errorf("Expected 1 authorize call, got %d", got)
+		}
+	})
+
+	t.Run("should retry on the new session host after a refresh", func(t *testing.T) {
+		var oldHits, newHits atomic.Int32
+		newHost := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			newHits.Add(1)
+			if r.Header.Get("Authorization") != "00000000-0000-0000-0000-000000000002" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"n":2}`)
+		}))
+		defer newHost.Close()
+		oldHost := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			oldHits.Add(1)
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer oldHost.Close()
+		var authorizes atomic.Int32
+		authSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			server := oldHost.URL
+			if authorizes.Add(1) > 1 {
+				server = newHost.URL
+			}
+			fmt.Fprintf(w, `{"Token":"00000000-0000-0000-0000-%012d","TTL":1800,"Server":%q}`, authorizes.Load(), server)
+		}))
+		defer authSrv.Close()
+
+		ts, err := auth.NewTokenSource(auth.Credentials{}, auth.WithAuthURL(authSrv.URL))
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		c, err := NewClient(WithBaseURLSource(ts.Server), WithTransportChain(AuthRefreshMiddleware(ts, nil)))
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		var out map[string]int
+		if err := c.DoJSON(context.Background(), http.MethodPost, "/api/Orders/GetOrdersById", nil, map[string]int{"n": 1}, &out); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if out["n"] != 2 || oldHits.Load() != 1 || newHits.Load() != 1 {
+			t.Errorf("Expected the retry to reach the new host, got %v, old=%d new=%d", out, oldHits.Load(), newHits.Load())
+		}
+	})
+}
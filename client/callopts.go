@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Priority — приоритет вызова для RateLimitMiddleware. Нулевое значение — PriorityNormal.
+type Priority int
+
+const (
+	// PriorityLow — фоновые задачи: вызов ждёт, пока в лимитере стоят вызовы с более высоким приоритетом.
+	PriorityLow Priority = iota - 1
+	PriorityNormal
+	// PriorityHigh — интерактивные вызовы: обычные и фоновые пропускают их вперёд.
+	PriorityHigh
+)
+
+// CallOptions — настройки одного вызова DoJSON поверх настроек клиента.
+type CallOptions struct {
+	// Timeout ограничивает весь вызов вместе с повторами. Таймаут http.Client клиента
+	// (30 секунд по умолчанию) продолжает действовать на каждую попытку.
+	Timeout time.Duration
+	// Retry заменяет политику повторов клиента (см. WithRetryPolicy).
+	Retry *RetryPolicy
+	// Header добавляется к заголовкам запроса и перекрывает заголовки по умолчанию.
+	Header   http.Header
+	Priority Priority
+}
+
+// CallOption меняет CallOptions одного вызова. Билдеры принимают их через WithOptions.
+type CallOption func(*CallOptions)
+
+// CallTimeout ограничивает время вызова вместе с повторами.
+func CallTimeout(d time.Duration) CallOption {
+	return func(o *CallOptions) { o.Timeout = d }
+}
+
+// CallRetryPolicy заменяет политику повторов клиента для вызова.
+func CallRetryPolicy(p RetryPolicy) CallOption {
+	return func(o *CallOptions) { o.Retry = &p }
+}
+
+// CallNoRetry отключает повторы для вызова.
+func CallNoRetry() CallOption {
+	return CallRetryPolicy(NoRetry())
+}
+
+// CallHeader задаёт заголовок запроса.
+func CallHeader(key, value string) CallOption {
+	return func(o *CallOptions) {
+		if o.Header == nil {
+			o.Header = make(http.Header)
+		}
+		o.Header.Set(key, value)
+	}
+}
+
+// CallPriority задаёт приоритет вызова в RateLimitMiddleware.
+func CallPriority(p Priority) CallOption {
+	return func(o *CallOptions) { o.Priority = p }
+}
+
+type callOptionsKey struct{}
+
+// WithCallOptions применяет opts поверх опций, уже лежащих в ctx. DoJSON читает их из контекста
+// запроса, прослойки цепочки — через CallOptionsFrom.
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+	o := CallOptionsFrom(ctx)
+	o.Header = o.Header.Clone()
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return context.WithValue(ctx, callOptionsKey{}, o)
+}
+
+// CallOptionsFrom возвращает опции вызова из ctx; без опций — нулевое значение.
+func CallOptionsFrom(ctx context.Context) CallOptions {
+	o, _ := ctx.Value(callOptionsKey{}).(CallOptions)
+	return o
+}
+
+// priorityGate придерживает вызовы, пока перед лимитером ждут вызовы с более высоким приоритетом.
+type priorityGate struct {
+	mu      sync.Mutex
+	waiting map[Priority]int
+	changed chan struct{}
+}
+
+func newPriorityGate() *priorityGate {
+	return &priorityGate{waiting: make(map[Priority]int), changed: make(chan struct{})}
+}
+
+// enter регистрирует вызов и ждёт, пока не останется ожидающих с более высоким приоритетом.
+// После успешного enter нужно вызвать leave.
+func (g *priorityGate) enter(ctx context.Context, p Priority) error {
+	g.mu.Lock()
+	g.waiting[p]++
+	for {
+		if !g.blocked(p) {
+			g.mu.Unlock()
+			return nil
+		}
+		changed := g.changed
+		g.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			g.leave(p)
+			return ctx.Err()
+		case <-changed:
+		}
+		g.mu.Lock()
+	}
+}
+
+func (g *priorityGate) leave(p Priority) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.waiting[p]--
+	close(g.changed)
+	g.changed = make(chan struct{})
+}
+
+func (g *priorityGate) blocked(p Priority) bool {
+	for q, n := range g.waiting {
+		if q > p && n > 0 {
+			return true
+		}
+	}
+	return false
+}
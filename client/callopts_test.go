@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCallOptions(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	t.Run("should send call headers over the defaults", func(t *testing.T) {
+		var got http.Header
+		c := newRetryTestClient(t, policy, func(w http.ResponseWriter, r *http.Request) {
+			got = r.Header.Clone()
+		})
+		ctx := WithCallOptions(context.Background(), CallHeader("X-Request-Id", "abc"), CallHeader("Accept", "text/json"))
+		if err := c.DoJSON(ctx, http.MethodGet, "/api/Inventory/GetStockLocations", nil, nil, nil); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if got.Get("X-Request-Id") != "abc" || got.Get("Accept") != "text/json" {
+			t.Errorf("unexpected headers %v", got)
+		}
+	})
+
+	t.Run("should override defaults from non-canonical header keys", func(t *testing.T) {
+		var got http.Header
+		c := newRetryTestClient(t, policy, func(w http.ResponseWriter, r *http.Request) {
+			got = r.Header.Clone()
+		})
+		raw := func(o *CallOptions) { o.Header = http.Header{"accept": {"text/json"}} }
+		if err := c.DoJSON(WithCallOptions(context.Background(), raw), http.MethodGet, "/api/Inventory/GetStockLocations", nil, nil, nil); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if v := got.Values("Accept"); len(v) != 1 || v[0] != "text/json" {
+			t.Errorf("Expected a single overridden Accept header, got %v", v)
+		}
+	})
+
+	t.Run("should disable retries for the call", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newRetryTestClient(t, policy, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+		})
+		ctx := WithCallOptions(context.Background(), CallNoRetry())
+		if err := c.DoJSON(ctx, http.MethodGet, "/api/Inventory/GetStockLocations", nil, nil, nil); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+		if calls.Load() != 1 {
+			t.Errorf("Expected 1 call, got %d", calls.Load())
+		}
+	})
+
+	t.Run("should bound the call with the timeout", func(t *testing.T) {
+		c := newRetryTestClient(t, policy, func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		})
+		ctx := WithCallOptions(context.Background(), CallTimeout(20*time.Millisecond))
+		err := c.DoJSON(ctx, http.MethodGet, "/api/Inventory/GetStockLocations", nil, nil, nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected deadline exceeded, got %v", err)
+		}
+	})
+
+	t.Run("should merge options already in the context", func(t *testing.T) {
+		ctx := WithCallOptions(context.Background(), CallHeader("A", "1"), CallPriority(PriorityLow))
+		ctx = WithCallOptions(ctx, CallHeader("B", "2"))
+		o := CallOptionsFrom(ctx)
+		if o.Header.Get("A") != "1" || o.Header.Get("B") != "2" || o.Priority != PriorityLow {
+			t.Errorf("unexpected options %+v", o)
+		}
+	})
+}
+
+func TestPriorityGate(t *testing.T) {
+	g := newPriorityGate()
+	ctx := context.Background()
+	if err := g.enter(ctx, PriorityHigh); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []Priority
+	var wg sync.WaitGroup
+	for _, p := range []Priority{PriorityLow, PriorityNormal} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := g.enter(ctx, p); err != nil {
+				t.Errorf("Expected nil error, got %v", err)
+				return
+			}
+			mu.Lock()
+			order = append(order, p)
+			mu.Unlock()
+			// держим место, пока low не убедится, что normal впереди
+			time.Sleep(10 * time.Millisecond)
+			g.leave(p)
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	if len(order) != 0 {
+		t.Errorf("Expected lower priorities to wait for high, got %v", order)
+	}
+	mu.Unlock()
+	g.leave(PriorityHigh)
+	wg.Wait()
+	if len(order) != 2 || order[0] != PriorityNormal {
+		t.Errorf("Expected normal before low, got %v", order)
+	}
+
+	t.Run("should stop waiting when the context is done", func(t *testing.T) {
+		if err := g.enter(ctx, PriorityHigh); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		defer g.leave(PriorityHigh)
+		cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		if err := g.enter(cctx, PriorityLow); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected deadline exceeded, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// CallStats собирает сведения об одном вызове DoJSON из разных прослоек цепочки:
+// число попыток (RetryPolicyMiddleware) и время ожидания лимитера (RateLimitMiddleware).
+// Прослойки, которым эти данные нужны (трейсинг, логирование), кладут CallStats в контекст
+// через WithCallStats до вызова остальной цепочки.
+type CallStats struct {
+	attempts      atomic.Int32
+	logged        atomic.Int32
+	rateLimitWait atomic.Int64
+}
+
+type callStatsKey struct{}
+
+// WithCallStats возвращает контекст с CallStats; если в ctx он уже есть, переиспользует его.
+func WithCallStats(ctx context.Context) (context.Context, *CallStats) {
+	if s := CallStatsFrom(ctx); s != nil {
+		return ctx, s
+	}
+	s := &CallStats{}
+	return context.WithValue(ctx, callStatsKey{}, s), s
+}
+
+func CallStatsFrom(ctx context.Context) *CallStats {
+	s, _ := ctx.Value(callStatsKey{}).(*CallStats)
+	return s
+}
+
+// Attempts — число отправленных попыток (0, если RetryPolicyMiddleware не участвовал).
+func (s *CallStats) Attempts() int { return int(s.attempts.Load()) }
+
+// RateLimitWait — суммарное время ожидания в RateLimitMiddleware.
+func (s *CallStats) RateLimitWait() time.Duration { return time.Duration(s.rateLimitWait.Load()) }
+
+func (s *CallStats) addAttempt() {
+	if s != nil {
+		s.attempts.Add(1)
+	}
+}
+
+// logAttempt — номер попытки, которую видит LoggingMiddleware: считается самой прослойкой
+// и не зависит от того, есть ли в цепочке RetryPolicyMiddleware.
+func (s *CallStats) logAttempt() int {
+	return int(s.logged.Add(1))
+}
+
+func (s *CallStats) addRateLimitWait(d time.Duration) {
+	if s != nil {
+		s.rateLimitWait.Add(int64(d))
+	}
+}
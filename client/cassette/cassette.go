@@ -0,0 +1,307 @@
+// Package cassette records Linnworks HTTP exchanges to JSON or YAML files and replays them
+// offline. Plug it in with client.WithHTTPClient (and auth.WithHTTPClient for the session call).
+//
+//	rec, err := cassette.New("testdata/orders.yaml", cassette.ModeReplay)
+//	c, err := client.NewClient(client.WithHTTPClient(rec.HTTPClient()), client.WithBaseURL("https://eu-ext.linnworks.net"))
+//	defer rec.Stop()
+//
+// Requests are matched on method, path, query and the JSON body normalised to sorted keys.
+// Credentials (Authorization, applicationSecret, token, ...) are scrubbed before anything is written,
+// from JSON and non-JSON bodies alike; the session token is also masked wherever a body echoes it.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/MMC-BK/lw-api/redact"
+)
+
+type Mode int
+
+const (
+	// ModeReplay serves only recorded interactions and fails on anything else.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests through the real transport and records them, replacing the file on Stop.
+	ModeRecord
+	// ModeAuto replays when the cassette file exists and records otherwise.
+	ModeAuto
+)
+
+// ErrNoMatch is returned by RoundTrip in replay mode when no unused interaction matches.
+var ErrNoMatch = errors.New("cassette: no recorded interaction matches request")
+
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions" yaml:"interactions"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request" yaml:"request"`
+	Response Response `json:"response" yaml:"response"`
+}
+
+type Request struct {
+	Method  string      `json:"method" yaml:"method"`
+	Path    string      `json:"path" yaml:"path"`
+	Query   string      `json:"query,omitempty" yaml:"query,omitempty"`
+	Headers http.Header `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Body    string      `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+type Response struct {
+	Status  int         `json:"status" yaml:"status"`
+	Headers http.Header `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Body    string      `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+type Recorder struct {
+	path string
+	mode Mode
+	real http.RoundTripper
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+type Option func(*Recorder)
+
+// WithRealTransport sets the transport used in record mode, http.DefaultTransport by default.
+func WithRealTransport(rt http.RoundTripper) Option {
+	return func(r *Recorder) { r.real = rt }
+}
+
+// New opens the cassette at path. The format follows the extension: .yaml/.yml or JSON otherwise.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	if path == "" {
+		return nil, errors.New("cassette path is required")
+	}
+	r := &Recorder{path: path, mode: mode, real: http.DefaultTransport, cassette: &Cassette{}}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.mode == ModeAuto {
+		r.mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		}
+	}
+	if r.mode == ModeReplay {
+		c, err := load(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+		r.used = make([]bool, len(c.Interactions))
+	}
+	return r, nil
+}
+
+func (r *Recorder) Mode() Mode { return r.mode }
+
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	recorded := Request{
+		Method:  req.Method,
+		Path:    req.URL.Path,
+		Query:   req.URL.Query().Encode(),
+		Headers: scrubHeaders(req.Header),
+		Body:    maskToken(normalizeBody(body), req.Header.Get("Authorization")),
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, body, recorded)
+}
+
+func (r *Recorder) replay(req *http.Request, want Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, it := range r.cassette.Interactions {
+		if r.used[i] || !matches(it.Request, want) {
+			continue
+		}
+		r.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", it.Response.Status, http.StatusText(it.Response.Status)),
+			StatusCode:    it.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        it.Response.Headers.Clone(),
+			Body:          io.NopCloser(strings.NewReader(it.Response.Body)),
+			ContentLength: int64(len(it.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s?%s body=%s (cassette %s)", ErrNoMatch, want.Method, want.Path, want.Query, redact.Truncate(want.Body, 512), r.path)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte, recorded Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := r.real.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: recorded,
+		Response: Response{
+			Status:  resp.StatusCode,
+			Headers: scrubHeaders(resp.Header),
+			Body:    maskToken(string(redact.Secrets(respBody)), req.Header.Get("Authorization")),
+		},
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Stop writes the recorded interactions in record mode. In replay mode it reports
+// interactions that were never requested.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mode == ModeRecord {
+		return save(r.path, r.cassette)
+	}
+	var unused []string
+	for i, it := range r.cassette.Interactions {
+		if !r.used[i] {
+			unused = append(unused, it.Request.Method+" "+it.Request.Path)
+		}
+	}
+	if len(unused) > 0 {
+		return fmt.Errorf("cassette %s: %d interactions were not used: %s", r.path, len(unused), strings.Join(unused, ", "))
+	}
+	return nil
+}
+
+func matches(rec, want Request) bool {
+	return rec.Method == want.Method &&
+		rec.Path == want.Path &&
+		rec.Query == want.Query &&
+		normalizeBody([]byte(rec.Body)) == want.Body
+}
+
+// normalizeBody scrubs credentials and re-encodes JSON with sorted keys so that
+// field order and whitespace do not affect matching.
+func normalizeBody(body []byte) string {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return ""
+	}
+	if !json.Valid(body) {
+		return string(redact.Text(body))
+	}
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(redact.Secrets(body)))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return string(body)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+	return string(out)
+}
+
+// minMaskedToken keeps short Authorization values from masking unrelated text.
+const minMaskedToken = 8
+
+// maskToken replaces the session token from the Authorization header wherever the body
+// carries it, e.g. in plain-text error pages that key-based redaction does not parse.
+func maskToken(body, authorization string) string {
+	fields := strings.Fields(authorization)
+	if len(fields) == 0 {
+		return body
+	}
+	// "Bearer <token>" as well as the bare token Linnworks uses
+	token := fields[len(fields)-1]
+	if len(token) < minMaskedToken {
+		return body
+	}
+	return strings.ReplaceAll(body, token, redact.Mask)
+}
+
+func scrubHeaders(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	return redact.Headers(h)
+}
+
+func isYAML(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+func load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("cassette %s does not exist; record it first", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var c Cassette
+	if isYAML(path) {
+		err = yaml.Unmarshal(data, &c)
+	} else {
+		err = json.Unmarshal(data, &c)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+func save(path string, c *Cassette) error {
+	var data []byte
+	var err error
+	if isYAML(path) {
+		data, err = yaml.Marshal(c)
+	} else {
+		data, err = json.MarshalIndent(c, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,143 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/orders"
+	"github.com/MMC-BK/lw-api/processedorders"
+)
+
+const orderID = strfmt.UUID("6b1f7c2e-4a0d-4c55-9b1e-2f4d7a9c1e01")
+
+func newTestClient(t *testing.T, rec *Recorder, baseURL string) *client.Client {
+	t.Helper()
+	sign := func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("Authorization", "secret-session-token")
+			return next.RoundTrip(req)
+		})
+	}
+	c, err := client.NewClient(client.WithHTTPClient(rec.HTTPClient()), client.WithBaseURL(baseURL), client.WithTransportChain(sign))
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	return c
+}
+
+func TestReplay(t *testing.T) {
+	rec, err := New("testdata/orders.yaml", ModeReplay)
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	c := newTestClient(t, rec, "https://eu-ext.linnworks.net")
+
+	details, err := orders.NewOrders(c).GetOrdersById(context.Background()).PkOrderIds([]strfmt.UUID{orderID}).Do()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	if len(details) != 1 || details[0].NumOrderID != 1001 {
+		t.Errorf("Expected order 1001, got %+v", details)
+	}
+
+	page, err := processedorders.NewProcessedOrders(c).SearchProcessedOrders(context.Background()).
+		PageNumber(1).ResultsPerPage(50).SearchTerm("1001").Do()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	if page.TotalEntries != 1 || len(page.Data) != 1 {
+		t.Errorf("Expected one processed order, got %+v", page)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Errorf("Expected every interaction to be used, got %v", err)
+	}
+
+	_, err = orders.NewOrders(c).GetOrdersById(context.Background()).PkOrderIds([]strfmt.UUID{orderID}).Do()
+	if !errors.Is(err, ErrNoMatch) {
+		t.Errorf("Expected ErrNoMatch for an unrecorded request, got %v", err)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Token":"6b1f7c2e-0000-0000-0000-000000000001","Server":"https://eu-ext.linnworks.net","TTL":1800}`))
+	}))
+	path := filepath.Join(t.TempDir(), "auth.json")
+
+	rec, _ := New(path, ModeAuto)
+	if rec.Mode() != ModeRecord {
+		t.Fatalf("Expected record mode for a missing cassette")
+	}
+	c := newTestClient(t, rec, srv.URL)
+	in := map[string]string{"applicationId": "app", "applicationSecret": "s3cr3t", "token": "install-token"}
+	if err := c.DoJSON(context.Background(), http.MethodPost, "/api/Auth/AuthorizeByApplication", nil, in, nil); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	srv.Close()
+
+	data, _ := os.ReadFile(path)
+	for _, leaked := range []string{"s3cr3t", "install-token", "secret-session-token", "6b1f7c2e-0000"} {
+		if strings.Contains(string(data), leaked) {
+			t.Errorf("Expected %q to be scrubbed from the cassette", leaked)
+		}
+	}
+
+	replay, _ := New(path, ModeAuto)
+	c = newTestClient(t, replay, srv.URL)
+	// different key order: bodies are normalised before matching
+	in = map[string]string{"token": "install-token", "applicationSecret": "s3cr3t", "applicationId": "app"}
+	var out struct{ Server string }
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := c.DoJSON(ctx, http.MethodPost, "/api/Auth/AuthorizeByApplication", nil, in, &out); err != nil {
+		t.Fatalf("Expected replayed response, got %v", err)
+	}
+	if out.Server != "https://eu-ext.linnworks.net" {
+		t.Errorf("Expected recorded server, got %q", out.Server)
+	}
+}
+
+func TestRecordNonJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("session " + r.Header.Get("Authorization") + " rejected, token=install-token"))
+	}))
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "text.yaml")
+
+	rec, _ := New(path, ModeRecord)
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/api/Auth/AuthorizeByApplication", strings.NewReader("applicationSecret=s3cr3t&token=install-token"))
+	req.Header.Set("Authorization", "secret-session-token")
+	resp, err := rec.HTTPClient().Do(req)
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	resp.Body.Close()
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	for _, leaked := range []string{"s3cr3t", "install-token", "secret-session-token"} {
+		if strings.Contains(string(data), leaked) {
+			t.Errorf("Expected %q to be scrubbed from the cassette, got %s", leaked, data)
+		}
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
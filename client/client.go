@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 )
 
@@ -19,8 +21,20 @@ type MakeRequest interface {
 type Client struct {
 	hc      *http.Client
 	baseURL *url.URL
+	// baseURLSource, если задан, определяет хост на каждый запрос (например, из сессии)
+	baseURLSource BaseURLSource
+
+	// strict, если задан, сверяет ответы с моделями (WithStrictDecoding)
+	strict *StrictDecoding
+
+	mu         sync.Mutex
+	lastRawURL string
+	lastURL    *url.URL
 }
 
+// BaseURLSource отдаёт актуальный базовый URL API.
+type BaseURLSource func(ctx context.Context) (string, error)
+
 type Option func(*Client) error
 
 func NewClient(opts ...Option) (*Client, error) {
@@ -47,6 +61,29 @@ func WithBaseURL(raw string) Option {
 	}
 }
 
+// WithBaseURLSource берёт базовый URL из source перед каждым запросом, так что смена
+// хоста (например, после refresh сессии) подхватывается без пересоздания клиента.
+func WithBaseURLSource(source BaseURLSource) Option {
+	return func(c *Client) error {
+		if source == nil {
+			return errors.New("base url source is nil")
+		}
+		c.baseURLSource = source
+		return nil
+	}
+}
+
+// WithTransport задаёт базовый транспорт; должен идти до WithTransportChain.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) error {
+		if rt == nil {
+			return errors.New("transport is nil")
+		}
+		c.hc.Transport = rt
+		return nil
+	}
+}
+
 func WithTransportChain(mws ...RTMiddleware) Option {
 	return func(c *Client) error {
 		base := c.hc.Transport
@@ -60,7 +97,34 @@ func WithHTTPClient(hc *http.Client) Option {
 }
 
 func (c *Client) DoJSON(ctx context.Context, method, path string, query url.Values, in any, out any) error {
-	u := c.baseURL.ResolveReference(&url.URL{Path: path})
+	_, err := c.DoJSONWithMeta(ctx, method, path, query, in, out)
+	return err
+}
+
+// DoJSONWithMeta — DoJSON, который дополнительно отдаёт статус, заголовки и сырое тело ответа.
+// При *APIError и *DecodeError Response тоже возвращается; при сетевой ошибке он nil.
+func (c *Client) DoJSONWithMeta(ctx context.Context, method, path string, query url.Values, in any, out any) (*Response, error) {
+	start := time.Now()
+	// CallStats заполняют прослойки цепочки (попытки, ожидание лимитера)
+	ctx, stats := WithCallStats(ctx)
+	opts := CallOptionsFrom(ctx)
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	if opts.Retry != nil {
+		ctx = WithRetryPolicy(ctx, *opts.Retry)
+	}
+	if c.baseURLSource != nil {
+		// AuthRefreshMiddleware после refresh переносит запрос на новый хост сессии
+		ctx = context.WithValue(ctx, sessionHostKey{}, c)
+	}
+	base, err := c.resolveBaseURL(ctx)
+	if err != nil {
+		return nil, &NotSentError{Err: err}
+	}
+	u := base.ResolveReference(&url.URL{Path: path})
 	if query != nil {
 		u.RawQuery = query.Encode()
 	}
@@ -69,47 +133,115 @@ func (c *Client) DoJSON(ctx context.Context, method, path string, query url.Valu
 	if in != nil {
 		b, err := json.Marshal(in)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		body = bytes.NewReader(b)
 	}
 	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 	// Тут можно поставить общий User-Agent
 	// req.Header.Set("User-Agent", "linnworks-sdk-go/1.0")
+	for k, v := range opts.Header {
+		// Header можно собрать и литералом с ключами в любом регистре
+		req.Header[http.CanonicalHeaderKey(k)] = v
+	}
 
-	resp, err := c.hc.Do(req)
+	// trace видит все попытки RetryPolicyMiddleware: хуки httptrace складываются
+	tr := &sendTrace{}
+	resp, err := c.hc.Do(req.WithContext(tr.attach(ctx)))
 	if err != nil {
-		return err
+		if tr.notSent(err) {
+			return nil, &NotSentError{Err: err}
+		}
+		return nil, err
 	}
 	defer resp.Body.Close()
 
+	meta := &Response{
+		Method:     method,
+		Path:       path,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		RateLimit:  parseRateLimit(resp.Header),
+		RequestID:  parseRequestID(resp.Header),
+		Attempts:   stats.Attempts(),
+	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		meta.Duration = time.Since(start)
 		// единый разбор ошибок API
-		var apiErr struct {
-			Code    string `json:"code"`
-			Message string `json:"message"`
-		}
-		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
-		if apiErr.Message == "" {
-			apiErr.Message = resp.Status
-		}
-		return fmt.Errorf("api error: %d %s (%s)", resp.StatusCode, apiErr.Message, apiErr.Code)
+		return meta, newAPIError(req, resp)
+	}
+	meta.Body, err = io.ReadAll(resp.Body)
+	meta.Duration = time.Since(start)
+	if err != nil {
+		return meta, err
+	}
+	if out == nil {
+		return meta, nil
+	}
+	return meta, c.decode(meta, out)
+}
+
+// decode разбирает тело в out, в строгом режиме — со сверкой по модели.
+func (c *Client) decode(meta *Response, out any) error {
+	if c.strict == nil {
+		return meta.Decode(out)
 	}
-	if out != nil {
-		return json.NewDecoder(resp.Body).Decode(out)
+	err := c.strict.decode(meta.Method, meta.Path, meta.Body, out)
+	var driftErr *DriftError
+	if err != nil && !errors.As(err, &driftErr) {
+		return newDecodeError(meta, err)
 	}
-	return nil
+	return err
 }
 
-type APIError struct {
-	Status  int    `json:"-"`
-	Code    string `json:"code,omitempty"`
-	Message string `json:"message,omitempty"`
+type sessionHostKey struct{}
+
+// rehosted возвращает запрос, направленный на текущий хост из baseURLSource: после refresh
+// токена сессия может переехать на другой сервер. Если хост не менялся или Client
+// задан с фиксированным baseURL, возвращает сам req.
+func rehosted(req *http.Request) (*http.Request, error) {
+	c, ok := req.Context().Value(sessionHostKey{}).(*Client)
+	if !ok {
+		return req, nil
+	}
+	base, err := c.resolveBaseURL(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	if base.Scheme == req.URL.Scheme && base.Host == req.URL.Host {
+		return req, nil
+	}
+	out := req.Clone(req.Context())
+	out.URL.Scheme, out.URL.Host = base.Scheme, base.Host
+	out.Host = ""
+	return out, nil
 }
 
-func (e APIError) Error() string { return fmt.Sprintf("%d %s (%s)", e.Status, e.Message, e.Code) }
+func (c *Client) resolveBaseURL(ctx context.Context) (*url.URL, error) {
+	if c.baseURLSource == nil {
+		if c.baseURL == nil {
+			return nil, errors.New("base url is not configured")
+		}
+		return c.baseURL, nil
+	}
+	raw, err := c.baseURLSource(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("resolve base url: %w", err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lastURL != nil && c.lastRawURL == raw {
+		return c.lastURL, nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("resolve base url: %w", err)
+	}
+	c.lastRawURL, c.lastURL = raw, u
+	return u, nil
+}
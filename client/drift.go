@@ -0,0 +1,287 @@
+package client
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type DriftKind string
+
+const (
+	// DriftUnknownField — в ответе есть поле, которого нет в модели.
+	DriftUnknownField DriftKind = "unknown_field"
+	// DriftTypeMismatch — JSON-тип поля не совпадает с типом в модели.
+	DriftTypeMismatch DriftKind = "type_mismatch"
+)
+
+// Drift — одно расхождение ответа Linnworks со сгенерированной моделью.
+type Drift struct {
+	Method string
+	// Endpoint — путь запроса, например /api/Orders/GetOrdersById.
+	Endpoint string
+	Kind     DriftKind
+	// Field — JSON-путь поля; индексы массивов схлопнуты: $.Data[].NewField.
+	Field string
+	// Expected — Go-тип поля модели, Got — JSON-тип в ответе (для DriftTypeMismatch).
+	Expected string
+	Got      string
+}
+
+func (d Drift) String() string {
+	if d.Kind == DriftUnknownField {
+		return fmt.Sprintf("unknown field %s", d.Field)
+	}
+	return fmt.Sprintf("%s is %s, model expects %s", d.Field, d.Got, d.Expected)
+}
+
+// StrictDecoding включает сверку ответов с моделями. По умолчанию вызов не падает:
+// расхождения уходят в OnDrift (лог, метрика — см. lwotel.DriftCounter).
+type StrictDecoding struct {
+	// OnDrift вызывается синхронно из DoJSON для каждого расхождения.
+	OnDrift func(Drift)
+	// Fail — вернуть *DriftError из DoJSON (удобно в тестах). out при этом уже заполнен.
+	Fail bool
+}
+
+// DriftError возвращается DoJSON в строгом режиме с Fail.
+type DriftError struct {
+	Drifts []Drift
+}
+
+func (e *DriftError) Error() string {
+	if len(e.Drifts) == 0 {
+		return "schema drift"
+	}
+	parts := make([]string, len(e.Drifts))
+	for i, d := range e.Drifts {
+		parts[i] = d.String()
+	}
+	return fmt.Sprintf("schema drift in %s %s: %s", e.Drifts[0].Method, e.Drifts[0].Endpoint, strings.Join(parts, "; "))
+}
+
+// WithStrictDecoding включает строгий режим разбора ответов для клиента.
+func WithStrictDecoding(s StrictDecoding) Option {
+	return func(c *Client) error {
+		c.strict = &s
+		return nil
+	}
+}
+
+// decode разбирает тело как обычно и дополнительно сверяет его с типом out.
+func (s *StrictDecoding) decode(method, endpoint string, data []byte, out any) error {
+	decodeErr := json.Unmarshal(data, out)
+
+	var raw any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return decodeErr
+	}
+	w := driftWalker{seen: make(map[string]bool)}
+	w.walk("$", raw, reflect.TypeOf(out))
+	sort.Slice(w.drifts, func(i, j int) bool { return w.drifts[i].Field < w.drifts[j].Field })
+	for i := range w.drifts {
+		w.drifts[i].Method = method
+		w.drifts[i].Endpoint = endpoint
+		if s.OnDrift != nil {
+			s.OnDrift(w.drifts[i])
+		}
+	}
+	if decodeErr != nil {
+		return decodeErr
+	}
+	if s.Fail && len(w.drifts) > 0 {
+		return &DriftError{Drifts: w.drifts}
+	}
+	return nil
+}
+
+var (
+	jsonUnmarshaler = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+type driftWalker struct {
+	drifts []Drift
+	seen   map[string]bool
+}
+
+func (w *driftWalker) add(d Drift) {
+	key := string(d.Kind) + d.Field
+	if w.seen[key] {
+		return
+	}
+	w.seen[key] = true
+	w.drifts = append(w.drifts, d)
+}
+
+func (w *driftWalker) mismatch(path string, t reflect.Type, got string) {
+	w.add(Drift{Kind: DriftTypeMismatch, Field: path, Expected: t.String(), Got: got})
+}
+
+func (w *driftWalker) walk(path string, v any, t reflect.Type) {
+	if v == nil || t == nil {
+		return
+	}
+	for t.Kind() == reflect.Pointer {
+		if t.Implements(jsonUnmarshaler) {
+			return
+		}
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshaler) {
+		return
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshaler) {
+		if _, ok := v.(string); !ok {
+			w.mismatch(path, t, jsonType(v))
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			w.mismatch(path, t, jsonType(v))
+			return
+		}
+		fields := jsonFields(t)
+		for key, val := range obj {
+			f, ok := lookupField(fields, key)
+			if !ok {
+				w.add(Drift{Kind: DriftUnknownField, Field: path + "." + key, Got: jsonType(val)})
+				continue
+			}
+			w.walk(path+"."+key, val, f.Type)
+		}
+	case reflect.Map:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			w.mismatch(path, t, jsonType(v))
+			return
+		}
+		for key, val := range obj {
+			w.walk(path+"."+key, val, t.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte приходит base64-строкой
+			if _, ok := v.(string); !ok {
+				w.mismatch(path, t, jsonType(v))
+			}
+			return
+		}
+		arr, ok := v.([]any)
+		if !ok {
+			w.mismatch(path, t, jsonType(v))
+			return
+		}
+		for _, item := range arr {
+			w.walk(path+"[]", item, t.Elem())
+		}
+	case reflect.String:
+		if _, ok := v.(string); !ok {
+			w.mismatch(path, t, jsonType(v))
+		}
+	case reflect.Bool:
+		if _, ok := v.(bool); !ok {
+			w.mismatch(path, t, jsonType(v))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := v.(json.Number)
+		if !ok {
+			w.mismatch(path, t, jsonType(v))
+			return
+		}
+		if _, err := n.Int64(); err != nil {
+			w.mismatch(path, t, "fractional number")
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := v.(json.Number); !ok {
+			w.mismatch(path, t, jsonType(v))
+		}
+	}
+}
+
+// structFields кэширует jsonFields: reflect.Type -> map[string]reflect.StructField
+var structFields sync.Map
+
+// jsonFields — поля структуры по JSON-именам, включая встроенные (allOf в go-swagger).
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	if cached, ok := structFields.Load(t); ok {
+		return cached.(map[string]reflect.StructField)
+	}
+	out := make(map[string]reflect.StructField)
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k, v := range jsonFields(ft) {
+					if _, ok := out[k]; !ok {
+						out[k] = v
+					}
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		out[name] = f
+	}
+	structFields.Store(t, out)
+	return out
+}
+
+// lookupField ищет поле так же, как encoding/json: сначала точное имя, потом без учёта регистра.
+func lookupField(fields map[string]reflect.StructField, key string) (reflect.StructField, bool) {
+	if f, ok := fields[key]; ok {
+		return f, true
+	}
+	for name, f := range fields {
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
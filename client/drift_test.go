@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ordermodels "github.com/MMC-BK/lw-api/orders/models"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	body := `[{"NumOrderId":1,"Items":[{"SKU":"A","NewField":1},{"SKU":"B","NewField":2}]},{"NumOrderId":"2"}]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("clean") != "" {
+			w.Write([]byte(`[{"NumOrderId":1,"Items":[{"SKU":"A"}]}]`))
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	t.Run("should report unknown fields and type mismatches to OnDrift", func(t *testing.T) {
+		var drifts []Drift
+		c, err := NewClient(WithBaseURL(srv.URL), WithStrictDecoding(StrictDecoding{
+			OnDrift: func(d Drift) { drifts = append(drifts, d) },
+		}))
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		var out []ordermodels.OrderDetails
+		err = c.DoJSON(context.Background(), http.MethodPost, "/api/Orders/GetOrdersById", nil, nil, &out)
+		// retyped NumOrderId fails encoding/json as before, drift is reported anyway
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			t.Fatalf("Expected *json.UnmarshalTypeError, got %v", err)
+		}
+		if len(drifts) != 2 {
+			t.Fatalf("Expected 2 drifts, got %v", drifts)
+		}
+		if drifts[0].Kind != DriftUnknownField || drifts[0].Field != "$[].Items[].NewField" {
+			t.Errorf("unexpected drift %+v", drifts[0])
+		}
+		if drifts[1].Kind != DriftTypeMismatch || drifts[1].Field != "$[].NumOrderId" || drifts[1].Got != "string" {
+			t.Errorf("unexpected drift %+v", drifts[1])
+		}
+		if drifts[0].Endpoint != "/api/Orders/GetOrdersById" || drifts[0].Method != http.MethodPost {
+			t.Errorf("unexpected drift endpoint %+v", drifts[0])
+		}
+	})
+
+	t.Run("should return a DriftError when Fail is set", func(t *testing.T) {
+		c, err := NewClient(WithBaseURL(srv.URL), WithStrictDecoding(StrictDecoding{Fail: true}))
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		var out []struct {
+			NumOrderID any `json:"NumOrderId"`
+		}
+		err = c.DoJSON(context.Background(), http.MethodPost, "/api/Orders/GetOrdersById", nil, nil, &out)
+		var drift *DriftError
+		if !errors.As(err, &drift) {
+			t.Fatalf("Expected *DriftError, got %v", err)
+		}
+		if len(drift.Drifts) != 1 || drift.Drifts[0].Field != "$[].Items" {
+			t.Errorf("unexpected drifts %v", drift.Drifts)
+		}
+		if len(out) != 2 {
+			t.Errorf("Expected out to be decoded, got %v", out)
+		}
+	})
+
+	t.Run("should not report drift for a matching body", func(t *testing.T) {
+		c, err := NewClient(WithBaseURL(srv.URL), WithStrictDecoding(StrictDecoding{Fail: true}))
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		var out []ordermodels.OrderDetails
+		q := map[string][]string{"clean": {"1"}}
+		if err := c.DoJSON(context.Background(), http.MethodGet, "/api/Orders/GetOrdersById", q, nil, &out); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxErrorBody = 4 << 10
+
+// APIError описывает неуспешный ответ Linnworks. DoJSON возвращает *APIError.
+type APIError struct {
+	Status    int       `json:"-"`
+	Code      string    `json:"code,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	Method    string    `json:"-"`
+	Path      string    `json:"-"`
+	Body      string    `json:"-"`
+	RateLimit RateLimit `json:"-"`
+}
+
+// NotSentError — запрос не дошёл до Linnworks: ни одна попытка не успела отправить заголовки.
+// Такой вызов можно повторить, не опасаясь двойного выполнения. Сетевая ошибка без
+// NotSentError означает, что исход неизвестен.
+type NotSentError struct {
+	Err error
+}
+
+func (e *NotSentError) Error() string { return "not sent: " + e.Err.Error() }
+
+func (e *NotSentError) Unwrap() error { return e.Err }
+
+// IsNotSent сообщает, что запрос точно не был отправлен.
+func IsNotSent(err error) bool {
+	var ns *NotSentError
+	return errors.As(err, &ns)
+}
+
+// RateLimit — значения rate-limit заголовков ответа (пустые, если сервер их не прислал).
+type RateLimit struct {
+	Limit      string
+	Remaining  string
+	Reset      string
+	RetryAfter time.Duration
+}
+
+func (e APIError) Error() string {
+	msg := fmt.Sprintf("%d %s (%s)", e.Status, e.Message, e.Code)
+	if e.Method != "" || e.Path != "" {
+		msg = fmt.Sprintf("%s %s: %s", e.Method, e.Path, msg)
+	}
+	return msg
+}
+
+func newAPIError(req *http.Request, resp *http.Response) *APIError {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	e := &APIError{
+		Status:    resp.StatusCode,
+		Method:    req.Method,
+		Path:      req.URL.Path,
+		Body:      strings.TrimSpace(string(data)),
+		RateLimit: parseRateLimit(resp.Header),
+	}
+	_ = json.Unmarshal(data, e)
+	if e.Message == "" {
+		e.Message = resp.Status
+	}
+	return e
+}
+
+func parseRateLimit(h http.Header) RateLimit {
+	first := func(keys ...string) string {
+		for _, k := range keys {
+			if v := h.Get(k); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+	return RateLimit{
+		Limit:      first("X-RateLimit-Limit", "X-Rate-Limit-Limit"),
+		Remaining:  first("X-RateLimit-Remaining", "X-Rate-Limit-Remaining"),
+		Reset:      first("X-RateLimit-Reset", "X-Rate-Limit-Reset"),
+		RetryAfter: parseRetryAfter(h.Get("Retry-After")),
+	}
+}
+
+// parseRetryAfter понимает оба формата Retry-After: секунды и HTTP-дату.
+func parseRetryAfter(v string) time.Duration {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// AsAPIError достаёт *APIError из цепочки ошибок.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+func hasStatus(err error, statuses ...int) bool {
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return false
+	}
+	for _, s := range statuses {
+		if apiErr.Status == s {
+			return true
+		}
+	}
+	return false
+}
+
+func IsNotFound(err error) bool { return hasStatus(err, http.StatusNotFound) }
+
+// IsUnauthorized — 401: сессия недействительна, помогает обновление токена.
+func IsUnauthorized(err error) bool { return hasStatus(err, http.StatusUnauthorized) }
+
+// IsForbidden — 403: у приложения нет прав на метод, обновление токена не поможет.
+func IsForbidden(err error) bool { return hasStatus(err, http.StatusForbidden) }
+
+func IsRateLimited(err error) bool { return hasStatus(err, http.StatusTooManyRequests) }
+
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusBadRequest, http.StatusUnprocessableEntity)
+}
+
+func IsServerError(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.Status >= 500
+}
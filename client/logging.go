@@ -0,0 +1,90 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MMC-BK/lw-api/redact"
+)
+
+// сколько байт тела попадает в debug-лог
+const maxLoggedBody = 4 << 10
+
+// LoggingMiddleware пишет slog-запись на каждую попытку запроса: метод, путь, статус, время,
+// номер попытки и размеры тел. На уровне Debug добавляются тела запроса и ответа.
+// Authorization, секреты и персональные данные покупателя маскируются пакетом redact.
+//
+// Ставьте прослойку после RetryPolicyMiddleware, чтобы видеть каждую попытку отдельно.
+func LoggingMiddleware(logger *slog.Logger) RTMiddleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			// без Client в контексте нет CallStats: тогда запрос считается одной попыткой
+			ctx, stats := WithCallStats(req.Context())
+			attempt := stats.logAttempt()
+			debug := logger.Enabled(ctx, slog.LevelDebug)
+
+			var reqBody []byte
+			if req.Body != nil && (debug || req.ContentLength < 0) {
+				var err error
+				reqBody, err = io.ReadAll(req.Body)
+				req.Body.Close()
+				if err != nil {
+					return nil, err
+				}
+				req.Body = io.NopCloser(bytes.NewReader(reqBody))
+			}
+			reqSize := req.ContentLength
+			if reqBody != nil {
+				reqSize = int64(len(reqBody))
+			}
+
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("path", req.URL.Path),
+				slog.Duration("latency", time.Since(start)),
+				slog.Int("attempt", attempt),
+				slog.Int64("request_bytes", reqSize),
+			}
+			if debug {
+				attrs = append(attrs,
+					slog.Any("request_headers", redact.Headers(req.Header)),
+					slog.String("request_body", redact.Truncate(string(redact.JSON(reqBody)), maxLoggedBody)))
+			}
+
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				logger.LogAttrs(ctx, slog.LevelError, "linnworks request failed", attrs...)
+				return nil, err
+			}
+
+			respSize := resp.ContentLength
+			if debug {
+				respBody, rerr := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBody+1))
+				// отдаём вызывающему всё тело: прочитанную часть и остаток
+				resp.Body = struct {
+					io.Reader
+					io.Closer
+				}{io.MultiReader(bytes.NewReader(respBody), resp.Body), resp.Body}
+				if rerr == nil {
+					attrs = append(attrs, slog.String("response_body", redact.Truncate(string(redact.JSON(respBody)), maxLoggedBody)))
+				}
+			}
+			attrs = append(attrs, slog.Int("status", resp.StatusCode), slog.Int64("response_bytes", respSize))
+
+			level := slog.LevelInfo
+			if resp.StatusCode >= 400 {
+				level = slog.LevelWarn
+			}
+			logger.LogAttrs(ctx, level, "linnworks request", attrs...)
+			return resp, nil
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLoggingMiddleware(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"EmailAddress":"buyer@example.com","NumOrderId":42}`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	sign := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("Authorization", "secret-session-token")
+			return next.RoundTrip(req)
+		})
+	}
+	c, _ := NewClient(WithBaseURL(srv.URL), WithTransportChain(sign, LoggingMiddleware(logger)))
+
+	var out map[string]any
+	in := map[string]string{"applicationSecret": "s3cr3t", "OrderId": "o-1"}
+	if err := c.DoJSON(context.Background(), http.MethodPost, "/api/Orders/GetOrdersById", nil, in, &out); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	if out["EmailAddress"] != "buyer@example.com" {
+		t.Errorf("Expected the caller to receive the full body, got %v", out)
+	}
+
+	logged := buf.String()
+	for _, leaked := range []string{"secret-session-token", "s3cr3t", "buyer@example.com"} {
+		if strings.Contains(logged, leaked) {
+			t.Errorf("Expected %q to be redacted, got %s", leaked, logged)
+		}
+	}
+	for _, want := range []string{`"path":"/api/Orders/GetOrdersById"`, `"status":200`, `o-1`} {
+		if !strings.Contains(logged, want) {
+			t.Errorf("Expected log to contain %s, got %s", want, logged)
+		}
+	}
+}
+
+func TestLoggingMiddlewareAttempts(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls++; calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	t.Run("should number every attempt behind the retry middleware", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		c, _ := NewClient(WithBaseURL(srv.URL), WithTransportChain(RetryPolicyMiddleware(RetryPolicy{MaxRetries: 1}), LoggingMiddleware(logger)))
+		if err := c.DoJSON(context.Background(), http.MethodGet, "/api/Inventory/GetStockLocations", nil, nil, nil); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		for _, want := range []string{`"attempt":1`, `"attempt":2`} {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("Expected log to contain %s, got %s", want, buf.String())
+			}
+		}
+	})
+
+	t.Run("should log the attempt without a client context", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		hc := &http.Client{Transport: LoggingMiddleware(logger)(http.DefaultTransport)}
+		resp, err := hc.Get(srv.URL + "/api/Inventory/GetStockLocations")
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		resp.Body.Close()
+		if !strings.Contains(buf.String(), `"attempt":1`) {
+			t.Errorf("Expected log to contain the attempt, got %s", buf.String())
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/MMC-BK/lw-api/auth"
+	"github.com/go-openapi/strfmt"
 	"golang.org/x/time/rate"
 	"io"
 	"net/http"
@@ -13,14 +14,17 @@ import (
 // ========== 4) RT middleware (декораторы транспорта) ==========
 type RTMiddleware func(http.RoundTripper) http.RoundTripper
 
-type roundTripperFunc func(*http.Request) (*http.Response, error)
+// RoundTripperFunc позволяет использовать функцию как http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
 
-func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+func (f RoundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
 
-// Auth: вставляет заголовок
+// Auth: вставляет заголовок.
+//
+// Deprecated: используйте AuthRefreshMiddleware — он заново подписывает запрос после refresh.
 func AuthMiddleware(a auth.Authenticator) RTMiddleware {
 	return func(next http.RoundTripper) http.RoundTripper {
-		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
 			if err := a.SetAuth(req); err != nil {
 				return nil, err
 			}
@@ -29,10 +33,13 @@ func AuthMiddleware(a auth.Authenticator) RTMiddleware {
 	}
 }
 
-// Auto-refresh: если получили 401 — один раз форсим refresh и повторяем запрос
+// Auto-refresh: если получили 401 — один раз форсим refresh и повторяем запрос.
+// Заголовок Authorization при повторе не обновляется, поэтому снаружи AuthMiddleware не работает.
+//
+// Deprecated: используйте AuthRefreshMiddleware.
 func AutoRefreshOn401(ts auth.TokenSource) RTMiddleware {
 	return func(next http.RoundTripper) http.RoundTripper {
-		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
 			// тело запроса может потребоваться повторно прочитать
 			var bodyCopy []byte
 			if req.Body != nil {
@@ -49,7 +56,7 @@ func AutoRefreshOn401(ts auth.TokenSource) RTMiddleware {
 			}
 
 			// Пробуем форс-рефреш и повтор
-			if err := ts.ForceRefresh(req.Context()); err != nil {
+			if err := refreshStale(req.Context(), ts, strfmt.UUID(req.Header.Get("Authorization"))); err != nil {
 				return nil, fmt.Errorf("401 and refresh failed: %w", err)
 			}
 			// Восстанавливаем тело и повторяем
@@ -64,48 +71,22 @@ func AutoRefreshOn401(ts auth.TokenSource) RTMiddleware {
 // Прочие полезные прослойки
 func RateLimitMiddleware(rps int, per time.Duration) RTMiddleware {
 	lim := rate.NewLimiter(rate.Every(per/time.Duration(rps)), rps)
+	// вызовы с PriorityHigh встают к лимитеру раньше обычных, обычные — раньше PriorityLow
+	gate := newPriorityGate()
 	return func(next http.RoundTripper) http.RoundTripper {
-		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
-			if err := lim.Wait(req.Context()); err != nil {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			p := CallOptionsFrom(req.Context()).Priority
+			if err := gate.enter(req.Context(), p); err != nil {
 				return nil, err
 			}
-			return next.RoundTrip(req)
-		})
-	}
-}
-
-func RetryMiddleware(max int, backoff time.Duration) RTMiddleware {
-	return func(next http.RoundTripper) http.RoundTripper {
-		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
-			var lastErr error
-			var bodyBytes []byte
-			if req.Body != nil {
-				bodyBytes, _ = io.ReadAll(req.Body)
-				req.Body.Close()
-			}
-			for attempt := 0; attempt <= max; attempt++ {
-				if bodyBytes != nil {
-					req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
-				}
-				resp, err := next.RoundTrip(req)
-				// 5xx/429 — кандидаты на повтор, остальное — сразу выходим
-				if err == nil && resp.StatusCode < 500 && resp.StatusCode != 429 {
-					return resp, nil
-				}
-				if resp != nil {
-					resp.Body.Close()
-				}
-				lastErr = err
-				select {
-				case <-req.Context().Done():
-					return nil, req.Context().Err()
-				case <-time.After(time.Duration(attempt+1) * backoff):
-				}
-			}
-			if lastErr != nil {
-				return nil, lastErr
+			err := lim.Wait(req.Context())
+			gate.leave(p)
+			if err != nil {
+				return nil, err
 			}
-			return nil, fmt.Errorf("retry attempts exceeded")
+			CallStatsFrom(req.Context()).addRateLimitWait(time.Since(start))
+			return next.RoundTrip(req)
 		})
 	}
 }
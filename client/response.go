@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MMC-BK/lw-api/redact"
+)
+
+// maxDecodeExcerpt — сколько байт тела попадает в DecodeError.
+const maxDecodeExcerpt = 512
+
+// Response — метаданные ответа и сырое тело одного вызова.
+type Response struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Header     http.Header
+	// Body — тело успешного ответа целиком; для неуспешного см. APIError.Body.
+	Body      []byte
+	RateLimit RateLimit
+	// RequestID — идентификатор запроса на стороне сервера, если он прислан в заголовках.
+	RequestID string
+	// Attempts — число попыток с учётом повторов (0 без RetryPolicyMiddleware).
+	Attempts int
+	Duration time.Duration
+}
+
+// Decode разбирает Body в out; ошибка разбора — *DecodeError.
+func (r *Response) Decode(out any) error {
+	if err := json.Unmarshal(r.Body, out); err != nil {
+		return newDecodeError(r, err)
+	}
+	return nil
+}
+
+// MakeRequestWithMeta — MakeRequest, который отдаёт метаданные ответа. Его реализует *Client.
+type MakeRequestWithMeta interface {
+	MakeRequest
+	DoJSONWithMeta(ctx context.Context, method, path string, query url.Values, in any, out any) (*Response, error)
+}
+
+var _ MakeRequestWithMeta = (*Client)(nil)
+
+// DoJSONWithMeta вызывает c.DoJSONWithMeta, если c его поддерживает. Для остальных реализаций
+// MakeRequest (например, моков) в Response заполнены только Method, Path и Body.
+func DoJSONWithMeta(ctx context.Context, c MakeRequest, method, path string, query url.Values, in any, out any) (*Response, error) {
+	if mc, ok := c.(MakeRequestWithMeta); ok {
+		return mc.DoJSONWithMeta(ctx, method, path, query, in, out)
+	}
+	var raw json.RawMessage
+	if err := c.DoJSON(ctx, method, path, query, in, &raw); err != nil {
+		return nil, err
+	}
+	resp := &Response{Method: method, Path: path, Body: raw}
+	if out == nil || len(raw) == 0 {
+		return resp, nil
+	}
+	return resp, resp.Decode(out)
+}
+
+// DoRaw выполняет запрос без разбора тела; им пользуются методы DoRaw билдеров.
+func DoRaw(ctx context.Context, c MakeRequest, method, path string, query url.Values, in any) (*Response, error) {
+	return DoJSONWithMeta(ctx, c, method, path, query, in, nil)
+}
+
+// DecodeError — тело успешного ответа не разобралось в модель.
+type DecodeError struct {
+	Method string
+	Path   string
+	Status int
+	// Excerpt — начало тела после redact.JSON, не длиннее maxDecodeExcerpt байт.
+	Excerpt string
+	Err     error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decode %s %s response: %v; body: %s", e.Method, e.Path, e.Err, e.Excerpt)
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+func newDecodeError(r *Response, err error) *DecodeError {
+	return &DecodeError{Method: r.Method, Path: r.Path, Status: r.StatusCode, Excerpt: excerpt(r.Body), Err: err}
+}
+
+// excerpt маскирует секреты и PII до обрезки: обрезанный JSON уже не разобрать,
+// и redact пришлось бы искать пары по шаблону.
+func excerpt(body []byte) string {
+	s := strings.TrimSpace(string(redact.JSON(body)))
+	if len(s) <= maxDecodeExcerpt {
+		return s
+	}
+	cut := maxDecodeExcerpt
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
+}
+
+func parseRequestID(h http.Header) string {
+	for _, k := range []string{"X-Request-Id", "Request-Id", "X-Correlation-Id"} {
+		if v := h.Get(k); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDoJSONWithMeta(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Header().Set("X-RateLimit-Remaining", "149")
+		switch r.URL.Path {
+		case "/api/Orders/GetOrdersById":
+			w.Write([]byte(`[{"NumOrderId":1}]`))
+		case "/api/Orders/Mistyped":
+			w.Write([]byte(`{"NumOrderId":"x","CustomerInfo":{"Address":{"EmailAddress":"a@b.c","Town":"Leeds"}}}`))
+		case "/api/Orders/Broken":
+			w.Write([]byte(`<html>` + strings.Repeat("x", 1000) + `</html>`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	c, err := NewClient(WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	ctx := context.Background()
+
+	t.Run("should return status, headers and body", func(t *testing.T) {
+		var out []map[string]any
+		resp, err := c.DoJSONWithMeta(ctx, http.MethodPost, "/api/Orders/GetOrdersById", nil, nil, &out)
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if resp.StatusCode != http.StatusOK || resp.RequestID != "req-1" || resp.RateLimit.Remaining != "149" {
+			t.Errorf("unexpected response %+v", resp)
+		}
+		if string(resp.Body) != `[{"NumOrderId":1}]` || len(out) != 1 {
+			t.Errorf("unexpected body %q, out %v", resp.Body, out)
+		}
+	})
+
+	t.Run("should return metadata with api errors", func(t *testing.T) {
+		resp, err := c.DoJSONWithMeta(ctx, http.MethodPost, "/api/Orders/Missing", nil, nil, nil)
+		if !IsNotFound(err) {
+			t.Fatalf("Expected not found, got %v", err)
+		}
+		if resp == nil || resp.RequestID != "req-1" {
+			t.Errorf("unexpected response %+v", resp)
+		}
+	})
+
+	t.Run("should include path and body excerpt in decode errors", func(t *testing.T) {
+		var out []map[string]any
+		err := c.DoJSON(ctx, http.MethodPost, "/api/Orders/Broken", nil, nil, &out)
+		var decodeErr *DecodeError
+		if !errors.As(err, &decodeErr) {
+			t.Fatalf("Expected *DecodeError, got %v", err)
+		}
+		if decodeErr.Path != "/api/Orders/Broken" || !strings.HasPrefix(decodeErr.Excerpt, "<html>") {
+			t.Errorf("unexpected decode error %+v", decodeErr)
+		}
+		if len(decodeErr.Excerpt) > maxDecodeExcerpt+3 {
+			t.Errorf("Expected a truncated excerpt, got %d bytes", len(decodeErr.Excerpt))
+		}
+	})
+
+	t.Run("should mask PII in the decode error excerpt", func(t *testing.T) {
+		var out struct{ NumOrderId int }
+		err := c.DoJSON(ctx, http.MethodPost, "/api/Orders/Mistyped", nil, nil, &out)
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+		for _, leaked := range []string{"a@b.c", "Leeds"} {
+			if strings.Contains(err.Error(), leaked) {
+				t.Errorf("Expected %q to be redacted, got %s", leaked, err)
+			}
+		}
+	})
+
+	t.Run("should fall back to DoJSON for other implementations", func(t *testing.T) {
+		resp, err := DoRaw(ctx, onlyDoJSON{c}, http.MethodPost, "/api/Orders/GetOrdersById", nil, nil)
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if string(resp.Body) != `[{"NumOrderId":1}]` || resp.StatusCode != 0 {
+			t.Errorf("unexpected response %+v", resp)
+		}
+	})
+}
+
+// onlyDoJSON прячет DoJSONWithMeta клиента.
+type onlyDoJSON struct{ MakeRequest }
@@ -0,0 +1,225 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ========== Retry policy ==========
+
+// RetryAttempt — информация об одной попытке, передаётся в хук OnAttempt.
+type RetryAttempt struct {
+	Request  *http.Request
+	Attempt  int // 0 — первая попытка
+	Response *http.Response
+	Err      error
+	Retry    bool          // будет ли ещё попытка
+	Delay    time.Duration // пауза перед следующей попыткой
+}
+
+// RetryPolicy определяет, какие запросы и ответы повторять и с какой паузой.
+//
+// Запросы к небезопасным эндпоинтам (ProcessOrder, CreateOrders и т.п.) повторяются только
+// если транспорт упал до отправки запроса или сервер ответил 429 — в этих случаях Linnworks
+// запрос не выполнял.
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+	// MaxRetryAfter — верхняя граница для Retry-After; если сервер просит ждать дольше, ответ отдаётся как есть.
+	MaxRetryAfter time.Duration
+	// Safe классифицирует запрос как идемпотентный. По умолчанию — IsSafeToRetry.
+	Safe func(req *http.Request) bool
+	// OnAttempt вызывается после каждой попытки.
+	OnAttempt func(RetryAttempt)
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:    2,
+		BaseDelay:     200 * time.Millisecond,
+		MaxDelay:      5 * time.Second,
+		MaxRetryAfter: time.Minute,
+	}
+}
+
+// NoRetry — политика без повторов.
+func NoRetry() RetryPolicy { return RetryPolicy{} }
+
+// Префиксы имён эндпоинтов Linnworks, которые только читают данные (многие из них — POST).
+// После префикса должна идти заглавная буква: IssueRefund и Checkout — не проверки.
+var safeEndpointPrefixes = []string{"Get", "Search", "Check", "Validate", "Is"}
+
+// IsSafeToRetry — классификатор по умолчанию: GET/HEAD/OPTIONS и POST к читающим эндпоинтам.
+func IsSafeToRetry(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	name := req.URL.Path
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	for _, p := range safeEndpointPrefixes {
+		if rest, ok := strings.CutPrefix(name, p); ok && rest != "" && rest[0] >= 'A' && rest[0] <= 'Z' {
+			return true
+		}
+	}
+	return false
+}
+
+type retryPolicyKey struct{}
+
+// WithRetryPolicy переопределяет политику для запросов с этим контекстом.
+func WithRetryPolicy(ctx context.Context, p RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, p)
+}
+
+func retryPolicyFrom(ctx context.Context, def RetryPolicy) RetryPolicy {
+	if p, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy); ok {
+		return p
+	}
+	return def
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+	d := p.BaseDelay << attempt
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+	// equal jitter: половина фиксированная, половина случайная
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
+
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+func RetryPolicyMiddleware(policy RetryPolicy) RTMiddleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			p := retryPolicyFrom(req.Context(), policy)
+			stats := CallStatsFrom(req.Context())
+			if p.MaxRetries <= 0 {
+				stats.addAttempt()
+				return next.RoundTrip(req)
+			}
+			isSafe := p.Safe
+			if isSafe == nil {
+				isSafe = IsSafeToRetry
+			}
+			safe := isSafe(req)
+
+			var bodyBytes []byte
+			if req.Body != nil {
+				var err error
+				bodyBytes, err = io.ReadAll(req.Body)
+				req.Body.Close()
+				if err != nil {
+					return nil, err
+				}
+			}
+			for attempt := 0; ; attempt++ {
+				if bodyBytes != nil {
+					req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+				}
+				stats.addAttempt()
+				tr := &sendTrace{}
+				resp, err := next.RoundTrip(req.WithContext(tr.attach(req.Context())))
+
+				retry := false
+				var delay time.Duration
+				if attempt < p.MaxRetries && req.Context().Err() == nil {
+					switch {
+					case err != nil:
+						retry = safe || tr.notSent(err)
+					case resp.StatusCode == http.StatusTooManyRequests:
+						retry = true
+					case retryableStatus(resp.StatusCode):
+						retry = safe
+					}
+				}
+				if retry {
+					delay = p.backoff(attempt)
+					if resp != nil {
+						if ra := parseRetryAfter(resp.Header.Get("Retry-After")); ra > 0 {
+							if p.MaxRetryAfter > 0 && ra > p.MaxRetryAfter {
+								retry = false
+							} else if ra > delay {
+								delay = ra
+							}
+						}
+					}
+				}
+				if p.OnAttempt != nil {
+					p.OnAttempt(RetryAttempt{Request: req, Attempt: attempt, Response: resp, Err: err, Retry: retry, Delay: delay})
+				}
+				if !retry {
+					return resp, err
+				}
+				if resp != nil {
+					io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+				}
+				timer := time.NewTimer(delay)
+				select {
+				case <-req.Context().Done():
+					timer.Stop()
+					return nil, req.Context().Err()
+				case <-timer.C:
+				}
+			}
+		})
+	}
+}
+
+// RetryMiddleware сохраняет прежнюю сигнатуру поверх RetryPolicy: небезопасные запросы
+// больше не повторяются, Retry-After учитывается.
+//
+// Deprecated: используйте RetryPolicyMiddleware.
+func RetryMiddleware(max int, backoff time.Duration) RTMiddleware {
+	p := DefaultRetryPolicy()
+	p.MaxRetries = max
+	p.BaseDelay = backoff
+	return RetryPolicyMiddleware(p)
+}
+
+// sendTrace отслеживает, успел ли транспорт начать отправку запроса.
+type sendTrace struct {
+	started atomic.Bool
+	wrote   atomic.Bool
+}
+
+func (t *sendTrace) attach(ctx context.Context) context.Context {
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GetConn:      func(string) { t.started.Store(true) },
+		WroteHeaders: func() { t.wrote.Store(true) },
+	})
+}
+
+func (t *sendTrace) notSent(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if t.wrote.Load() {
+		return false
+	}
+	if t.started.Load() {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
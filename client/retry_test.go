@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(t *testing.T, policy RetryPolicy, h http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	c, err := NewClient(WithBaseURL(srv.URL), WithTransportChain(RetryPolicyMiddleware(policy)))
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	return c
+}
+
+func TestRetryPolicyMiddleware(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, MaxRetryAfter: time.Second}
+
+	t.Run("should retry safe endpoints on 5xx", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newRetryTestClient(t, policy, func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.Write([]byte(`[]`))
+		})
+		var out []any
+		if err := c.DoJSON(context.Background(), http.MethodPost, "/api/Orders/GetOrdersById", nil, map[string]any{}, &out); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if calls.Load() != 3 {
+			t.Errorf("Expected 3 calls, got %d", calls.Load())
+		}
+	})
+
+	t.Run("should not retry unsafe endpoints on 5xx", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newRetryTestClient(t, policy, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+		err := c.DoJSON(context.Background(), http.MethodPost, "/api/Orders/ProcessOrder", nil, map[string]any{}, nil)
+		if !IsServerError(err) {
+			t.Errorf("Expected server error, got %v", err)
+		}
+		if calls.Load() != 1 {
+			t.Errorf("Expected 1 call, got %d", calls.Load())
+		}
+	})
+
+	t.Run("should honour Retry-After on 429", func(t *testing.T) {
+		var calls atomic.Int32
+		var delays []time.Duration
+		p := policy
+		p.OnAttempt = func(a RetryAttempt) {
+			if a.Retry {
+				delays = append(delays, a.Delay)
+			}
+		}
+		c := newRetryTestClient(t, p, func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+		if err := c.DoJSON(context.Background(), http.MethodPost, "/api/Orders/ProcessOrder", nil, nil, nil); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if len(delays) != 1 || delays[0] != time.Second {
+			t.Errorf("Expected a single 1s delay, got %v", delays)
+		}
+	})
+
+	t.Run("context override should disable retries", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newRetryTestClient(t, policy, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+		ctx := WithRetryPolicy(context.Background(), NoRetry())
+		_ = c.DoJSON(ctx, http.MethodGet, "/api/Inventory/GetStockLocations", nil, nil, nil)
+		if calls.Load() != 1 {
+			t.Errorf("Expected 1 call, got %d", calls.Load())
+		}
+	})
+}
+
+func TestNotSentError(t *testing.T) {
+	t.Run("should mark requests that never reached the server", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		srv.Close()
+		c, err := NewClient(WithBaseURL(srv.URL))
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		err = c.DoJSON(context.Background(), http.MethodPost, "/api/Orders/ProcessOrder", nil, map[string]any{}, nil)
+		if err == nil || !IsNotSent(err) {
+			t.Errorf("Expected not sent error, got %v", err)
+		}
+	})
+
+	t.Run("should not mark requests dropped after sending", func(t *testing.T) {
+		c := newRetryTestClient(t, NoRetry(), func(w http.ResponseWriter, r *http.Request) {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+		})
+		err := c.DoJSON(context.Background(), http.MethodPost, "/api/Orders/ProcessOrder", nil, map[string]any{}, nil)
+		if err == nil || IsNotSent(err) {
+			t.Errorf("Expected a network error with unknown outcome, got %v", err)
+		}
+	})
+}
+
+func TestIsSafeToRetry(t *testing.T) {
+	t.Run("should match read-only endpoint names only", func(t *testing.T) {
+		cases := map[string]bool{
+			"/api/Orders/GetOrdersById":          true,
+			"/api/Orders/IsOrderLocked":          true,
+			"/api/Inventory/CheckStockAvailable": true,
+			"/api/Orders/IssueRefund":            false,
+			"/api/Orders/Checkout":               false,
+			"/api/Orders/Get":                    false,
+			"/api/Orders/ProcessOrder":           false,
+		}
+		for path, want := range cases {
+			req, _ := http.NewRequest(http.MethodPost, "https://eu-ext.linnworks.net"+path, nil)
+			if got := IsSafeToRetry(req); got != want {
+				t.Errorf("%s: expected %v, got %v", path, want, got)
+			}
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	oaerrors "github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+)
+
+// FieldError — одно нарушение спеки в теле запроса, найденное Validate сгенерированной модели.
+type FieldError struct {
+	// Path — JSON-путь поля, например $.request.SearchFilters[0].Value.
+	Path string
+	// Rule — нарушенное правило: required, enum, type (в т.ч. формат uuid/date-time), min, max, ...
+	Rule    string
+	Value   any
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	if e.Path == "" || e.Path == "$" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Validatable — сгенерированные go-swagger модели.
+type Validatable interface {
+	Validate(formats strfmt.Registry) error
+}
+
+type contextValidatable interface {
+	ContextValidate(ctx context.Context, formats strfmt.Registry) error
+}
+
+type skipValidationKey struct{}
+
+// WithoutValidation отключает проверку тела по спеке в build() билдеров для вызовов с этим контекстом.
+// Для горячих путей, где тело заведомо корректно; проверки обязательных полей в билдерах остаются.
+func WithoutValidation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipValidationKey{}, true)
+}
+
+func validationDisabled(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	skip, _ := ctx.Value(skipValidationKey{}).(bool)
+	return skip
+}
+
+// ValidatePayload прогоняет Validate и ContextValidate модели и возвращает по *FieldError
+// на каждое нарушение. Пустой результат — тело соответствует спеке или проверка отключена.
+func ValidatePayload(ctx context.Context, payload Validatable) []error {
+	if payload == nil || validationDisabled(ctx) {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	formats := strfmt.Default
+	var out []error
+	if err := payload.Validate(formats); err != nil {
+		out = append(out, fieldErrors(err)...)
+	}
+	if cv, ok := payload.(contextValidatable); ok {
+		if err := cv.ContextValidate(ctx, formats); err != nil {
+			out = append(out, fieldErrors(err)...)
+		}
+	}
+	return out
+}
+
+// FieldErrors достаёт все *FieldError из ошибки билдера (в т.ч. из errors.Join).
+func FieldErrors(err error) []*FieldError {
+	var out []*FieldError
+	var walk func(error)
+	walk = func(err error) {
+		if err == nil {
+			return
+		}
+		if fe, ok := err.(*FieldError); ok {
+			out = append(out, fe)
+			return
+		}
+		switch u := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, e := range u.Unwrap() {
+				walk(e)
+			}
+		case interface{ Unwrap() error }:
+			walk(u.Unwrap())
+		}
+	}
+	walk(err)
+	return out
+}
+
+func fieldErrors(err error) []error {
+	if ce, ok := err.(*oaerrors.CompositeError); ok {
+		var out []error
+		for _, e := range ce.Errors {
+			out = append(out, fieldErrors(e)...)
+		}
+		return out
+	}
+	if ve, ok := err.(*oaerrors.Validation); ok {
+		return []error{&FieldError{
+			Path:    jsonPath(ve.Name),
+			Rule:    rule(ve.Code()),
+			Value:   ve.Value,
+			Message: ve.Error(),
+		}}
+	}
+	return []error{&FieldError{Path: "$", Message: err.Error()}}
+}
+
+// jsonPath переводит имя go-openapi (request.SearchFilters.0.Value) в $.request.SearchFilters[0].Value.
+func jsonPath(name string) string {
+	if name == "" || name == "." {
+		return "$"
+	}
+	var b strings.Builder
+	b.WriteString("$")
+	for _, part := range strings.Split(name, ".") {
+		if part == "" {
+			continue
+		}
+		if _, err := strconv.Atoi(part); err == nil {
+			b.WriteString("[" + part + "]")
+			continue
+		}
+		b.WriteString("." + part)
+	}
+	return b.String()
+}
+
+func rule(code int32) string {
+	switch code {
+	case oaerrors.RequiredFailCode:
+		return "required"
+	case oaerrors.InvalidTypeCode:
+		return "type"
+	case oaerrors.EnumFailCode:
+		return "enum"
+	case oaerrors.PatternFailCode:
+		return "pattern"
+	case oaerrors.TooLongFailCode:
+		return "maxLength"
+	case oaerrors.TooShortFailCode:
+		return "minLength"
+	case oaerrors.MaxFailCode:
+		return "max"
+	case oaerrors.MinFailCode:
+		return "min"
+	case oaerrors.MaxItemsFailCode:
+		return "maxItems"
+	case oaerrors.MinItemsFailCode:
+		return "minItems"
+	case oaerrors.UniqueFailCode:
+		return "uniqueItems"
+	case oaerrors.MultipleOfFailCode:
+		return "multipleOf"
+	case oaerrors.ReadOnlyFailCode:
+		return "readOnly"
+	}
+	return "invalid"
+}
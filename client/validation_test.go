@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+
+	ordermodels "github.com/MMC-BK/lw-api/orders/models"
+	processedmodels "github.com/MMC-BK/lw-api/processedorders/models"
+)
+
+func TestValidatePayload(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should report uuid format errors with json paths", func(t *testing.T) {
+		errs := ValidatePayload(ctx, &ordermodels.OrdersGetOrdersByIDRequest{
+			PkOrderIds: []strfmt.UUID{"0b8f7d2e-4b1a-4c55-9d0e-3c2a1f6b7e11", "not-a-uuid"},
+		})
+		fields := FieldErrors(errors.Join(errs...))
+		if len(fields) != 1 {
+			t.Fatalf("Expected 1 field error, got %v", errs)
+		}
+		if fields[0].Path != "$.pkOrderIds[1]" || fields[0].Rule != "type" {
+			t.Errorf("unexpected field error %+v", fields[0])
+		}
+	})
+
+	t.Run("should report nested enum errors", func(t *testing.T) {
+		errs := ValidatePayload(ctx, &processedmodels.ProcessedOrdersSearchProcessedOrdersRequest{
+			Request: &processedmodels.SearchProcessedOrdersRequest{
+				SearchSorting: &processedmodels.SearchSorting{SortDirection: "UP"},
+			},
+		})
+		fields := FieldErrors(errors.Join(errs...))
+		if len(fields) != 1 {
+			t.Fatalf("Expected 1 field error, got %v", errs)
+		}
+		if fields[0].Path != "$.request.SearchSorting.SortDirection" || fields[0].Rule != "enum" {
+			t.Errorf("unexpected field error %+v", fields[0])
+		}
+	})
+
+	t.Run("should accept valid payloads", func(t *testing.T) {
+		errs := ValidatePayload(ctx, &ordermodels.OrdersGetOrdersByIDRequest{
+			PkOrderIds: []strfmt.UUID{"0b8f7d2e-4b1a-4c55-9d0e-3c2a1f6b7e11"},
+		})
+		if len(errs) != 0 {
+			t.Errorf("Expected no errors, got %v", errs)
+		}
+	})
+
+	t.Run("should skip validation when disabled in context", func(t *testing.T) {
+		errs := ValidatePayload(WithoutValidation(ctx), &ordermodels.OrdersGetOrdersByIDRequest{
+			PkOrderIds: []strfmt.UUID{"not-a-uuid"},
+		})
+		if len(errs) != 0 {
+			t.Errorf("Expected no errors, got %v", errs)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+// Command lwcoverage reports which Linnworks swagger operations have an SDK builder.
+//
+//	go run ./cmd/lwcoverage > coverage.json
+//	go run ./cmd/lwcoverage -baseline coverage.json -text
+//
+// -spec defaults to third_party/linnworks.json, the full Linnworks spec as pulled; it also takes
+// the spec url. Coverage is only meaningful against the full spec: a trimmed one overstates it
+// and reports existing builders as calling paths that are not in the spec.
+//
+// The JSON report lists every operation with its builder, request and response models and
+// any mismatch against the spec. With -baseline it exits 1 when an operation regressed.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/MMC-BK/lw-api/coverage"
+)
+
+func main() {
+	var (
+		specSrc  = flag.String("spec", "third_party/linnworks.json", "full swagger spec file or http(s) url")
+		root     = flag.String("root", ".", "module root to scan")
+		out      = flag.String("o", "", "write the JSON report to this file instead of stdout")
+		text     = flag.Bool("text", false, "print a human-readable summary instead of JSON")
+		baseline = flag.String("baseline", "", "previous JSON report; exit 1 on regressions")
+	)
+	flag.Parse()
+	if *specSrc == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	rep, err := coverage.Analyze(*specSrc, *root)
+	if err != nil {
+		fail(err)
+	}
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if *text {
+		printText(w, rep)
+	} else {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rep); err != nil {
+			fail(err)
+		}
+	}
+
+	if *baseline != "" {
+		data, err := os.ReadFile(*baseline)
+		if err != nil {
+			fail(err)
+		}
+		var base coverage.Report
+		if err := json.Unmarshal(data, &base); err != nil {
+			fail(fmt.Errorf("baseline %s: %w", *baseline, err))
+		}
+		if regressions := rep.Regressions(&base); len(regressions) > 0 {
+			for _, r := range regressions {
+				fmt.Fprintln(os.Stderr, "regression:", r)
+			}
+			os.Exit(1)
+		}
+	}
+}
+
+func printText(w io.Writer, rep *coverage.Report) {
+	s := rep.Summary
+	fmt.Fprintf(w, "%d/%d operations implemented (%.1f%%), %d with issues\n\n", s.Implemented, s.Operations, s.Coverage*100, s.WithIssues)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "GROUP\tIMPLEMENTED\tOPERATIONS\tCOVERAGE")
+	groups := make([]string, 0, len(s.Groups))
+	for name := range s.Groups {
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+	for _, name := range groups {
+		g := s.Groups[name]
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f%%\n", name, g.Implemented, g.Operations, g.Coverage*100)
+	}
+	tw.Flush()
+	for _, op := range rep.Operations {
+		for _, issue := range op.Issues {
+			fmt.Fprintf(w, "\n%s %s: %s", op.Method, op.Path, issue)
+		}
+	}
+	for _, b := range rep.UnknownBuilders {
+		fmt.Fprintf(w, "\n%s calls %s %s, which is not in the spec", b.Builder, b.Method, b.Path)
+	}
+	fmt.Fprintln(w)
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "lwcoverage:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,692 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/swag"
+
+	"github.com/MMC-BK/lw-api/internal/swagger"
+)
+
+const header = "// Code generated by lwgen. DO NOT EDIT."
+
+// stdImports maps standard library package names used by generated code to import paths.
+var stdImports = map[string]string{
+	"context": "context",
+	"errors":  "errors",
+	"http":    "net/http",
+	"url":     "net/url",
+	"strconv": "strconv",
+	"time":    "time",
+}
+
+type generator struct {
+	spec   *swagger.Spec
+	models *swagger.ModelIndex
+
+	// Group is the path segment after /api/, e.g. "Orders".
+	Group string
+	// Branch is the Go type the constructors hang off, e.g. "Orders".
+	Branch string
+	// Dir is the package directory builders are written to.
+	Dir        string
+	Package    string
+	ModelsPath string
+	ClientPath string
+	// Only limits generation to these endpoint names when not empty.
+	Only map[string]bool
+	// Check reports changes without touching the disk.
+	Check bool
+}
+
+type report struct {
+	Written   []string
+	Unchanged []string
+	Removed   []string
+	Skipped   []string
+}
+
+func (r *report) Changed() bool { return len(r.Written)+len(r.Removed) > 0 }
+
+// handWritten is what the package already declares outside lwgen output.
+type handWritten struct {
+	types   map[string]bool
+	methods map[string]map[string]bool
+	// generated lists file names produced by lwgen.
+	generated map[string]bool
+}
+
+func (h *handWritten) hasMethod(recv, name string) bool {
+	return h.methods[recv][name]
+}
+
+func scanPackage(dir string) (*handWritten, error) {
+	h := &handWritten{
+		types:     make(map[string]bool),
+		methods:   make(map[string]map[string]bool),
+		generated: make(map[string]bool),
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") || strings.HasSuffix(e.Name(), "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if bytes.HasPrefix(src, []byte(header)) {
+			h.generated[e.Name()] = true
+			continue
+		}
+		f, err := parser.ParseFile(fset, e.Name(), src, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, s := range d.Specs {
+					if ts, ok := s.(*ast.TypeSpec); ok {
+						h.types[ts.Name.Name] = true
+					}
+				}
+			case *ast.FuncDecl:
+				if d.Recv == nil || len(d.Recv.List) == 0 {
+					continue
+				}
+				recv := receiverName(d.Recv.List[0].Type)
+				if h.methods[recv] == nil {
+					h.methods[recv] = make(map[string]bool)
+				}
+				h.methods[recv][d.Name.Name] = true
+			}
+		}
+	}
+	return h, nil
+}
+
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if id, ok := expr.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
+func fileName(endpoint string) string {
+	return strings.ToLower(goName(endpoint)) + "_gen.go"
+}
+
+// goName turns an endpoint name into the Go identifier of its constructor:
+// ProcessOrder_RequiredBatchScans becomes ProcessOrderRequiredBatchScans.
+func goName(endpoint string) string {
+	return strings.ReplaceAll(endpoint, "_", "")
+}
+
+// run generates one file per endpoint. Endpoints whose builder type is declared by hand are
+// skipped, and generated methods (the constructor included) that are declared by hand in
+// another file are left out.
+func (g *generator) run() (*report, error) {
+	hw, err := scanPackage(g.Dir)
+	if err != nil {
+		return nil, err
+	}
+	rep := &report{}
+	want := make(map[string]bool)
+	seen := make(map[string]bool)
+
+	for _, ep := range g.spec.Endpoints(g.Group) {
+		if len(g.Only) > 0 && !g.Only[ep.Name] {
+			continue
+		}
+		if seen[ep.Name] {
+			rep.Skipped = append(rep.Skipped, fmt.Sprintf("%s %s: duplicate endpoint name", ep.Method, ep.Path))
+			continue
+		}
+		seen[ep.Name] = true
+		name := fileName(ep.Name)
+		if hw.types[goName(ep.Name)+"RequestBuilder"] {
+			rep.Skipped = append(rep.Skipped, ep.Name+": hand-written")
+			continue
+		}
+		src, err := g.render(ep, hw)
+		if err != nil {
+			rep.Skipped = append(rep.Skipped, fmt.Sprintf("%s: %v", ep.Name, err))
+			if hw.generated[name] {
+				// keep the previous output rather than dropping a working builder
+				want[name] = true
+			}
+			continue
+		}
+		want[name] = true
+		if err := g.write(name, src, rep); err != nil {
+			return nil, err
+		}
+	}
+
+	var stale []string
+	for name := range hw.generated {
+		if !want[name] && (len(g.Only) == 0 || g.onlyFile(name)) {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+	for _, name := range stale {
+		rep.Removed = append(rep.Removed, name)
+		if !g.Check {
+			if err := os.Remove(filepath.Join(g.Dir, name)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return rep, nil
+}
+
+// readAllowlist reads endpoint names, one per line. Blank lines and lines starting with # are ignored.
+func readAllowlist(path string) (map[string]bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			names[line] = true
+		}
+	}
+	return names, nil
+}
+
+func (g *generator) onlyFile(name string) bool {
+	for ep := range g.Only {
+		if fileName(ep) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) write(name string, src []byte, rep *report) error {
+	path := filepath.Join(g.Dir, name)
+	old, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(old, src) {
+		rep.Unchanged = append(rep.Unchanged, name)
+		return nil
+	}
+	rep.Written = append(rep.Written, name)
+	if g.Check {
+		return nil
+	}
+	if err := os.MkdirAll(g.Dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, src, 0o644)
+}
+
+// piece is one top-level declaration; methods are dropped when declared by hand.
+type piece struct {
+	recv, name string
+	code       string
+}
+
+type field struct {
+	Setter   string
+	GoName   string
+	JSONName string
+	Type     string
+	// Param is the setter parameter type; differs from Type for optional pointers to scalars.
+	Param    string
+	Doc      string
+	Required bool
+	Format   string
+}
+
+func (g *generator) render(ep swagger.Endpoint, hw *handWritten) ([]byte, error) {
+	builder := goName(ep.Name) + "RequestBuilder"
+	var body *swagger.Parameter
+	var query []*swagger.Parameter
+	for _, p := range ep.Op.Parameters {
+		switch p.In {
+		case "body":
+			body = p
+		case "query":
+			query = append(query, p)
+		case "header":
+			// Authorization is set by the client
+		default:
+			return nil, fmt.Errorf("unsupported %q parameter %s", p.In, p.Name)
+		}
+	}
+	if body != nil && len(query) > 0 {
+		return nil, errors.New("body and query parameters together are not supported")
+	}
+
+	var model *swagger.ModelType
+	var fields []field
+	var err error
+	if body != nil {
+		model, fields, err = g.bodyFields(body)
+	} else {
+		fields, err = g.queryFields(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	outType, outVar, outRet, err := g.responseType(ep.Op)
+	if err != nil {
+		return nil, err
+	}
+
+	recv := strings.ToLower(g.Branch[:1])
+	var pieces []piece
+	add := func(recvType, name, code string) {
+		pieces = append(pieces, piece{recv: recvType, name: name, code: code})
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s calls %s %s.\n", builder, ep.Method, ep.Path)
+	if doc := firstLine(ep.Summary); doc != "" {
+		fmt.Fprintf(&b, "//\n// %s\n", strings.TrimSuffix(doc, ".")+".")
+	}
+	if ep.Op.Deprecated {
+		b.WriteString("//\n// Deprecated: the endpoint is deprecated in the Linnworks spec.\n")
+	}
+	fmt.Fprintf(&b, "type %s struct {\n\tctx context.Context\n\tclient lw_api.MakeRequest\n", builder)
+	if model != nil {
+		fmt.Fprintf(&b, "\tdata *models.%s\n", model.GoName)
+	} else {
+		b.WriteString("\tquery url.Values\n")
+	}
+	b.WriteString("\terr []error\n\topts []lw_api.CallOption\n}\n")
+	add("", "", b.String())
+
+	b.Reset()
+	fmt.Fprintf(&b, "func (%s %s) %s(ctx context.Context) *%s {\n\treturn &%s{\n\t\tctx: ctx,\n\t\tclient: %s.c,\n", recv, g.Branch, goName(ep.Name), builder, builder, recv)
+	if model != nil {
+		fmt.Fprintf(&b, "\t\tdata: &models.%s{},\n", model.GoName)
+	} else {
+		b.WriteString("\t\tquery: url.Values{},\n")
+	}
+	b.WriteString("\t\terr: make([]error, 0),\n\t}\n}\n")
+	add(g.Branch, goName(ep.Name), b.String())
+
+	for _, f := range fields {
+		b.Reset()
+		fmt.Fprintf(&b, "// %s sets %s.", f.Setter, f.JSONName)
+		// go-swagger repeats the json name when the spec has no description
+		if doc := strings.TrimSuffix(f.Doc, "."); doc != "" && !strings.EqualFold(strings.ReplaceAll(doc, " ", ""), f.JSONName) {
+			fmt.Fprintf(&b, " %s.", doc)
+		}
+		fmt.Fprintf(&b, "\nfunc (b *%s) %s(value %s) *%s {\n\tif b == nil {\n\t\treturn nil\n\t}\n", builder, f.Setter, f.Param, builder)
+		switch {
+		case model == nil:
+			fmt.Fprintf(&b, "\tb.query.Set(%q, %s)\n", f.JSONName, f.Format)
+		case f.Param != f.Type:
+			fmt.Fprintf(&b, "\tb.data.%s = &value\n", f.GoName)
+		default:
+			fmt.Fprintf(&b, "\tb.data.%s = value\n", f.GoName)
+		}
+		b.WriteString("\treturn b\n}\n")
+		add(builder, f.Setter, b.String())
+	}
+
+	add(builder, "RetryPolicy", fmt.Sprintf(`// RetryPolicy overrides the client retry policy for this call.
+func (b *%[1]s) RetryPolicy(policy lw_api.RetryPolicy) *%[1]s {
+	return b.WithOptions(lw_api.CallRetryPolicy(policy))
+}
+`, builder))
+
+	add(builder, "WithOptions", fmt.Sprintf(`// WithOptions applies per-call options such as a timeout, extra headers or a rate limit priority.
+func (b *%[1]s) WithOptions(opts ...lw_api.CallOption) *%[1]s {
+	if b == nil {
+		return nil
+	}
+	b.opts = append(b.opts, opts...)
+	return b
+}
+`, builder))
+
+	add(builder, "requestContext", fmt.Sprintf(`func (b *%s) requestContext(ctx context.Context) context.Context {
+	return lw_api.WithCallOptions(ctx, b.opts...)
+}
+`, builder))
+
+	b.Reset()
+	built := "url.Values"
+	if model != nil {
+		built = "*models." + model.GoName
+	}
+	fmt.Fprintf(&b, "func (b *%s) build() (%s, error) {\n\tif b == nil {\n\t\treturn nil, errors.New(\"builder is nil\")\n\t}\n", builder, built)
+	b.WriteString("\terrs := make([]error, len(b.err))\n\tcopy(errs, b.err)\n")
+	for _, f := range fields {
+		if !f.Required {
+			continue
+		}
+		var cond string
+		if model == nil {
+			cond = fmt.Sprintf("!b.query.Has(%q)", f.JSONName)
+		} else if cond = zeroCheck("b.data."+f.GoName, f.Type); cond == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "\tif %s {\n\t\terrs = append(errs, errors.New(%q))\n\t}\n", cond, f.JSONName+" is required")
+	}
+	if hw.hasMethod(builder, "requiredField") {
+		b.WriteString("\terrs = append(errs, b.requiredField()...)\n")
+	}
+	if model != nil {
+		b.WriteString("\terrs = append(errs, lw_api.ValidatePayload(b.ctx, b.data)...)\n")
+		b.WriteString("\tif len(errs) > 0 {\n\t\treturn nil, errors.Join(errs...)\n\t}\n\treturn b.data, nil\n}\n")
+	} else {
+		b.WriteString("\tif len(errs) > 0 {\n\t\treturn nil, errors.Join(errs...)\n\t}\n\treturn b.query, nil\n}\n")
+	}
+	add(builder, "build", b.String())
+
+	b.Reset()
+	queryArg, bodyArg := "nil", "req"
+	if model == nil {
+		queryArg, bodyArg = "req", "nil"
+	}
+	call := fmt.Sprintf("b.client.DoJSON(b.requestContext(b.ctx), http.Method%s, %q, %s, %s, ", methodConst(ep.Method), ep.Path, queryArg, bodyArg)
+	if outType == "" {
+		fmt.Fprintf(&b, "func (b *%s) Do() error {\n\tif b == nil {\n\t\treturn errors.New(\"builder is nil\")\n\t}\n", builder)
+		b.WriteString("\treq, err := b.build()\n\tif err != nil {\n\t\treturn err\n\t}\n")
+		fmt.Fprintf(&b, "\treturn %snil)\n}\n", call)
+	} else {
+		fmt.Fprintf(&b, "func (b *%s) Do() (%s, error) {\n\tif b == nil {\n\t\treturn %s, errors.New(\"builder is nil\")\n\t}\n", builder, outType, zeroValue(outType))
+		fmt.Fprintf(&b, "\treq, err := b.build()\n\tif err != nil {\n\t\treturn %s, err\n\t}\n", zeroValue(outType))
+		fmt.Fprintf(&b, "\tvar out %s\n\tif err := %s&out); err != nil {\n\t\treturn %s, err\n\t}\n\treturn %s, nil\n}\n", outVar, call, zeroValue(outType), outRet)
+	}
+	add(builder, "Do", b.String())
+
+	b.Reset()
+	fmt.Fprintf(&b, "// DoRaw sends the request and returns the undecoded response with its status and headers.\nfunc (b *%s) DoRaw() (*lw_api.Response, error) {\n\tif b == nil {\n\t\treturn nil, errors.New(\"builder is nil\")\n\t}\n", builder)
+	b.WriteString("\treq, err := b.build()\n\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	fmt.Fprintf(&b, "\treturn lw_api.DoRaw(b.requestContext(b.ctx), b.client, http.Method%s, %q, %s, %s)\n}\n", methodConst(ep.Method), ep.Path, queryArg, bodyArg)
+	add(builder, "DoRaw", b.String())
+
+	var src strings.Builder
+	for _, p := range pieces {
+		if p.name != "" && hw.hasMethod(p.recv, p.name) {
+			continue
+		}
+		src.WriteString("\n")
+		src.WriteString(p.code)
+	}
+	return g.assemble(src.String())
+}
+
+func (g *generator) bodyFields(p *swagger.Parameter) (*swagger.ModelType, []field, error) {
+	def := p.Schema.RefName()
+	if def == "" {
+		return nil, nil, fmt.Errorf("body parameter %s is not a $ref", p.Name)
+	}
+	model := g.models.Lookup(def)
+	if model == nil {
+		return nil, nil, fmt.Errorf("model for definition %s not found", def)
+	}
+	required := make(map[string]bool)
+	if d := g.spec.Definitions[def]; d != nil {
+		for _, name := range d.Required {
+			required[name] = true
+		}
+	}
+	var fields []field
+	for _, mf := range model.Fields {
+		param := mf.Type
+		if elem, ok := strings.CutPrefix(mf.Type, "*"); ok && !strings.HasPrefix(elem, "models.") {
+			param = elem
+		}
+		fields = append(fields, field{
+			Setter:   setterName(mf.GoName),
+			GoName:   mf.GoName,
+			JSONName: mf.JSONName,
+			Type:     mf.Type,
+			Param:    param,
+			Doc:      firstLine(mf.Doc),
+			Required: required[mf.JSONName],
+		})
+	}
+	return model, fields, nil
+}
+
+func (g *generator) queryFields(params []*swagger.Parameter) ([]field, error) {
+	var fields []field
+	for _, p := range params {
+		typ, err := scalarType(p.Type, p.Format)
+		if err != nil {
+			return nil, fmt.Errorf("query parameter %s: %w", p.Name, err)
+		}
+		fields = append(fields, field{
+			Setter:   setterName(swag.ToGoName(p.Name)),
+			JSONName: p.Name,
+			Type:     typ,
+			Param:    typ,
+			Doc:      firstLine(p.Description),
+			Required: p.Required,
+			Format:   formatValue("value", typ),
+		})
+	}
+	return fields, nil
+}
+
+// responseType returns the Do result type, the decoded variable type and the return expression.
+func (g *generator) responseType(op *swagger.Operation) (string, string, string, error) {
+	var s *swagger.Schema
+	for _, code := range []string{"200", "201", "default"} {
+		if r := op.Responses[code]; r != nil && r.Schema != nil {
+			s = r.Schema
+			break
+		}
+	}
+	if s == nil {
+		return "", "", "", nil
+	}
+	if def := s.RefName(); def != "" {
+		m := g.models.Lookup(def)
+		if m == nil {
+			return "", "", "", fmt.Errorf("response model for definition %s not found", def)
+		}
+		return "*models." + m.GoName, "models." + m.GoName, "&out", nil
+	}
+	if s.Type == "array" {
+		if s.Items == nil {
+			return "", "", "", errors.New("response array has no items")
+		}
+		var elem string
+		if def := s.Items.RefName(); def != "" {
+			m := g.models.Lookup(def)
+			if m == nil {
+				return "", "", "", fmt.Errorf("response model for definition %s not found", def)
+			}
+			elem = "models." + m.GoName
+		} else {
+			var err error
+			if elem, err = scalarType(s.Items.Type, s.Items.Format); err != nil {
+				return "", "", "", err
+			}
+		}
+		return "[]" + elem, "[]" + elem, "out", nil
+	}
+	if s.Type == "object" {
+		return "map[string]any", "map[string]any", "out", nil
+	}
+	typ, err := scalarType(s.Type, s.Format)
+	if err != nil {
+		return "", "", "", err
+	}
+	return typ, typ, "out", nil
+}
+
+func (g *generator) assemble(decls string) ([]byte, error) {
+	body := "package " + g.Package + "\n" + decls
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", body, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("generated code does not parse: %w", err)
+	}
+	paths := map[string]string{
+		"strfmt": "github.com/go-openapi/strfmt",
+		"models": g.ModelsPath,
+		"lw_api": g.ClientPath,
+	}
+	for k, v := range stdImports {
+		paths[k] = v
+	}
+	used := make(map[string]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok && paths[id.Name] != "" {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+
+	var std, third, local []string
+	for name := range used {
+		switch {
+		case stdImports[name] != "":
+			std = append(std, fmt.Sprintf("%q", paths[name]))
+		case name == "strfmt":
+			third = append(third, fmt.Sprintf("%q", paths[name]))
+		case name == "lw_api":
+			local = append(local, fmt.Sprintf("lw_api %q", paths[name]))
+		default:
+			local = append(local, fmt.Sprintf("%q", paths[name]))
+		}
+	}
+	var groups []string
+	for _, group := range [][]string{std, third, local} {
+		if len(group) == 0 {
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool { return importPath(group[i]) < importPath(group[j]) })
+		groups = append(groups, "\t"+strings.Join(group, "\n\t")+"\n")
+	}
+	var out strings.Builder
+	out.WriteString(header + "\n\npackage " + g.Package + "\n\nimport (\n")
+	out.WriteString(strings.Join(groups, "\n"))
+	out.WriteString(")\n")
+	out.WriteString(decls)
+	return format.Source([]byte(out.String()))
+}
+
+func importPath(spec string) string {
+	return spec[strings.Index(spec, `"`):]
+}
+
+func scalarType(typ, format string) (string, error) {
+	switch typ {
+	case "string":
+		switch format {
+		case "uuid":
+			return "strfmt.UUID", nil
+		case "date-time":
+			return "strfmt.DateTime", nil
+		case "date":
+			return "strfmt.Date", nil
+		}
+		return "string", nil
+	case "integer":
+		if format == "int64" {
+			return "int64", nil
+		}
+		return "int32", nil
+	case "number":
+		if format == "float" {
+			return "float32", nil
+		}
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	}
+	return "", fmt.Errorf("unsupported type %q", typ)
+}
+
+func formatValue(v, typ string) string {
+	switch typ {
+	case "string":
+		return v
+	case "strfmt.UUID", "strfmt.DateTime", "strfmt.Date":
+		return v + ".String()"
+	case "int32":
+		return "strconv.FormatInt(int64(" + v + "), 10)"
+	case "int64":
+		return "strconv.FormatInt(" + v + ", 10)"
+	case "float32":
+		return "strconv.FormatFloat(float64(" + v + "), 'f', -1, 32)"
+	case "float64":
+		return "strconv.FormatFloat(" + v + ", 'f', -1, 64)"
+	case "bool":
+		return "strconv.FormatBool(" + v + ")"
+	}
+	return v
+}
+
+// zeroCheck returns the condition for a missing required body field, or "" when it cannot be told apart.
+func zeroCheck(expr, typ string) string {
+	switch {
+	case strings.HasPrefix(typ, "*"):
+		return expr + " == nil"
+	case strings.HasPrefix(typ, "[]"), strings.HasPrefix(typ, "map["):
+		return "len(" + expr + ") == 0"
+	case typ == "string", typ == "strfmt.UUID":
+		return expr + ` == ""`
+	case typ == "strfmt.DateTime":
+		return "time.Time(" + expr + ").IsZero()"
+	case strings.HasPrefix(typ, "int"), strings.HasPrefix(typ, "float"):
+		return expr + " == 0"
+	}
+	return ""
+}
+
+func zeroValue(typ string) string {
+	switch {
+	case strings.HasPrefix(typ, "*"), strings.HasPrefix(typ, "[]"), strings.HasPrefix(typ, "map["):
+		return "nil"
+	case typ == "string", typ == "strfmt.UUID":
+		return `""`
+	case typ == "bool":
+		return "false"
+	case typ == "strfmt.DateTime", typ == "strfmt.Date":
+		return typ + "{}"
+	}
+	return "0"
+}
+
+func methodConst(method string) string {
+	return method[:1] + strings.ToLower(method[1:])
+}
+
+// setterName avoids clashing with the fixed builder methods.
+func setterName(name string) string {
+	if name == "Do" || name == "DoRaw" || name == "RetryPolicy" || name == "WithOptions" {
+		return "Set" + name
+	}
+	return name
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
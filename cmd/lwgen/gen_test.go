@@ -0,0 +1,221 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/MMC-BK/lw-api/internal/swagger"
+)
+
+func newTestGenerator(t *testing.T, dir string) *generator {
+	t.Helper()
+	s, err := swagger.Load("testdata/spec.json")
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	models, err := swagger.LoadModels("../../orders/models")
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	return &generator{
+		spec:       s,
+		models:     models,
+		Group:      "Orders",
+		Branch:     "Orders",
+		Dir:        dir,
+		Package:    "orders",
+		ModelsPath: "github.com/MMC-BK/lw-api/orders/models",
+		ClientPath: "github.com/MMC-BK/lw-api/client",
+	}
+}
+
+func read(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	return string(data)
+}
+
+func TestGenerator(t *testing.T) {
+	t.Run("should generate body and query builders", func(t *testing.T) {
+		dir := t.TempDir()
+		rep, err := newTestGenerator(t, dir).run()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		want := []string{"getordernotes_gen.go", "getordersbyid_gen.go", "lockorder_gen.go", "setordershippinginfo_gen.go"}
+		if !slices.Equal(rep.Written, want) {
+			t.Errorf("Expected %v, got %v", want, rep.Written)
+		}
+		if len(rep.Skipped) != 1 || !strings.HasPrefix(rep.Skipped[0], "UploadOrderFile") {
+			t.Errorf("Expected UploadOrderFile to be skipped, got %v", rep.Skipped)
+		}
+
+		notes := read(t, filepath.Join(dir, "getordernotes_gen.go"))
+		for _, s := range []string{
+			"func (b *GetOrderNotesRequestBuilder) OrderID(value strfmt.UUID)",
+			`b.query.Set("OrderId", value.String())`,
+			`errors.New("OrderId is required")`,
+			`http.MethodGet, "/api/Orders/GetOrderNotes", req, nil, &out)`,
+			"Do() ([]models.OrderNote, error)",
+			`lw_api.DoRaw(b.requestContext(b.ctx), b.client, http.MethodGet, "/api/Orders/GetOrderNotes", req, nil)`,
+		} {
+			if !strings.Contains(notes, s) {
+				t.Errorf("Expected generated code to contain %q", s)
+			}
+		}
+		shipping := read(t, filepath.Join(dir, "setordershippinginfo_gen.go"))
+		for _, s := range []string{
+			"data   *models.OrdersSetOrderShippingInfoRequest",
+			"if b.data.Info == nil {",
+			`if b.data.OrderID == "" {`,
+			"errs = append(errs, lw_api.ValidatePayload(b.ctx, b.data)...)",
+			`http.MethodPost, "/api/Orders/SetOrderShippingInfo", nil, req, &out)`,
+			"Do() (*models.OrderDetails, error)",
+		} {
+			if !strings.Contains(shipping, s) {
+				t.Errorf("Expected generated code to contain %q", s)
+			}
+		}
+		if lock := read(t, filepath.Join(dir, "lockorder_gen.go")); !strings.Contains(lock, "Do() error") {
+			t.Error("Expected Do without a result for an endpoint without response schema")
+		}
+	})
+
+	t.Run("should be idempotent", func(t *testing.T) {
+		dir := t.TempDir()
+		if _, err := newTestGenerator(t, dir).run(); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		g := newTestGenerator(t, dir)
+		g.Check = true
+		rep, err := g.run()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if rep.Changed() || len(rep.Unchanged) != 4 {
+			t.Errorf("Expected no changes, got %+v", rep)
+		}
+	})
+
+	t.Run("should preserve hand-written overrides", func(t *testing.T) {
+		dir := t.TempDir()
+		if _, err := newTestGenerator(t, dir).run(); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		handWritten := `package orders
+
+type GetOrdersByIdRequestBuilder struct{}
+
+func (o Orders) LockOrder() *LockOrderRequestBuilder { return nil }
+
+func (b *LockOrderRequestBuilder) OrderIds(ids []string) *LockOrderRequestBuilder { return b }
+`
+		if err := os.WriteFile(filepath.Join(dir, "overrides.go"), []byte(handWritten), 0o644); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		rep, err := newTestGenerator(t, dir).run()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if !slices.Equal(rep.Removed, []string{"getordersbyid_gen.go"}) {
+			t.Errorf("Expected the generated GetOrdersById to be removed, got %v", rep.Removed)
+		}
+		if !slices.Equal(rep.Written, []string{"lockorder_gen.go"}) {
+			t.Errorf("Expected only lockorder_gen.go to change, got %v", rep.Written)
+		}
+		lock := read(t, filepath.Join(dir, "lockorder_gen.go"))
+		if strings.Contains(lock, ") OrderIds(") || strings.Contains(lock, "func (o Orders) LockOrder(") {
+			t.Error("Expected the hand-written OrderIds setter and constructor to be left out")
+		}
+		if !strings.Contains(lock, "type LockOrderRequestBuilder struct") {
+			t.Error("Expected the rest of the LockOrder builder to be generated")
+		}
+		if _, err := os.Stat(filepath.Join(dir, "overrides.go")); err != nil {
+			t.Errorf("Expected hand-written file to be kept, got %v", err)
+		}
+	})
+}
+
+func TestRequiredField(t *testing.T) {
+	t.Run("should call a hand-written requiredField from build", func(t *testing.T) {
+		dir := t.TempDir()
+		handWritten := `package orders
+
+import "errors"
+
+func (b *LockOrderRequestBuilder) requiredField() []error {
+	return []error{errors.New("orderIds is required")}
+}
+`
+		if err := os.WriteFile(filepath.Join(dir, "lockorder.go"), []byte(handWritten), 0o644); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if _, err := newTestGenerator(t, dir).run(); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if lock := read(t, filepath.Join(dir, "lockorder_gen.go")); !strings.Contains(lock, "errs = append(errs, b.requiredField()...)") {
+			t.Error("Expected build to append the requiredField errors")
+		}
+		if notes := read(t, filepath.Join(dir, "getordernotes_gen.go")); strings.Contains(notes, "requiredField") {
+			t.Error("Expected no requiredField call without a hand-written method")
+		}
+	})
+}
+
+func TestReadAllowlist(t *testing.T) {
+	t.Run("should skip comments and blank lines", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "endpoints.txt")
+		if err := os.WriteFile(path, []byte("# orders\nLockOrder\n\n  GetOrderNotes  \n"), 0o644); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		names, err := readAllowlist(path)
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if len(names) != 2 || !names["LockOrder"] || !names["GetOrderNotes"] {
+			t.Errorf("Expected LockOrder and GetOrderNotes, got %v", names)
+		}
+	})
+}
+
+func TestGeneratedUpToDate(t *testing.T) {
+	const specPath = "../../third_party/linnworks.json"
+	if _, err := os.Stat(specPath); errors.Is(err, os.ErrNotExist) {
+		t.Skip("the Linnworks spec is not pulled into third_party")
+	}
+	s, err := swagger.Load(specPath)
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	g := newTestGenerator(t, "../../orders")
+	g.spec = s
+	g.Check = true
+	if g.Only, err = readAllowlist("../../orders/lwgen_endpoints.txt"); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	rep, err := g.run()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	if rep.Changed() || len(rep.Skipped) > 0 {
+		t.Errorf("Generated builders are out of date, run go generate: written %v, removed %v, skipped %v", rep.Written, rep.Removed, rep.Skipped)
+	}
+}
+
+func TestFileName(t *testing.T) {
+	t.Run("should drop underscores from endpoint names", func(t *testing.T) {
+		if got := goName("ProcessOrder_RequiredBatchScans"); got != "ProcessOrderRequiredBatchScans" {
+			t.Errorf("Expected ProcessOrderRequiredBatchScans, got %s", got)
+		}
+		if got := fileName("ProcessOrder_RequiredBatchScans"); got != "processorderrequiredbatchscans_gen.go" {
+			t.Errorf("Expected processorderrequiredbatchscans_gen.go, got %s", got)
+		}
+	})
+}
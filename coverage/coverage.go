@@ -0,0 +1,352 @@
+// Package coverage compares the SDK request builders with the Linnworks swagger spec.
+//
+//	rep, err := coverage.Analyze("third_party/linnworks.json", ".")
+//	json.NewEncoder(os.Stdout).Encode(rep)
+//
+// The spec must be the full upstream one (the pulled file or its url), not the list of
+// operations lwgen generates: every operation without a builder counts against coverage.
+//
+// Builders are found statically: every DoJSON call with a literal /api/ path in a request
+// group package (a directory next to a models directory) counts as an implemented operation.
+package coverage
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/MMC-BK/lw-api/internal/swagger"
+)
+
+type Report struct {
+	Summary    Summary     `json:"summary"`
+	Operations []Operation `json:"operations"`
+	// UnknownBuilders call paths that are not in the spec.
+	UnknownBuilders []Builder `json:"unknownBuilders,omitempty"`
+	// UnusedRequestModels are go-swagger request models no builder sends.
+	UnusedRequestModels []string `json:"unusedRequestModels,omitempty"`
+}
+
+type Summary struct {
+	Operations  int                     `json:"operations"`
+	Implemented int                     `json:"implemented"`
+	WithIssues  int                     `json:"withIssues"`
+	Coverage    float64                 `json:"coverage"`
+	Groups      map[string]GroupSummary `json:"groups"`
+}
+
+type GroupSummary struct {
+	Operations  int     `json:"operations"`
+	Implemented int     `json:"implemented"`
+	Coverage    float64 `json:"coverage"`
+}
+
+type Operation struct {
+	Group       string `json:"group"`
+	Name        string `json:"name"`
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+	Implemented bool   `json:"implemented"`
+	// Builder is the SDK type making the call, e.g. orders.GetOrdersByIdRequestBuilder.
+	Builder string `json:"builder,omitempty"`
+	// RequestModel and ResponseModel are what the builder sends and decodes into.
+	RequestModel  string `json:"requestModel,omitempty"`
+	ResponseModel string `json:"responseModel,omitempty"`
+	// SpecRequestModel and SpecResponseModel are the Go model names the spec calls for.
+	SpecRequestModel  string   `json:"specRequestModel,omitempty"`
+	SpecResponseModel string   `json:"specResponseModel,omitempty"`
+	QueryParams       []string `json:"queryParams,omitempty"`
+	Issues            []string `json:"issues,omitempty"`
+}
+
+func (o Operation) key() string { return o.Method + " " + strings.ToLower(o.Path) }
+
+type Builder struct {
+	Builder string `json:"builder"`
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+}
+
+// Analyze loads the spec from a file or URL and scans the SDK source under root.
+func Analyze(specSource, root string) (*Report, error) {
+	s, err := swagger.Load(specSource)
+	if err != nil {
+		return nil, err
+	}
+	calls, err := scanSDK(root)
+	if err != nil {
+		return nil, err
+	}
+	return analyze(s, root, calls)
+}
+
+func analyze(s *swagger.Spec, root string, calls []builderCall) (*Report, error) {
+	byPath := make(map[string]builderCall)
+	for _, c := range calls {
+		byPath[strings.ToLower(c.Path)] = c
+	}
+	matched := make(map[string]bool)
+	sent := make(map[string]bool)
+	for _, c := range calls {
+		sent[baseType(c.Request)] = true
+	}
+
+	rep := &Report{Summary: Summary{Groups: make(map[string]GroupSummary)}}
+	models := make(map[string]*swagger.ModelIndex)
+	var unused []string
+
+	for _, group := range groups(s) {
+		mi, err := loadGroupModels(root, group)
+		if err != nil {
+			return nil, err
+		}
+		models[group] = mi
+		gs := GroupSummary{}
+		for _, ep := range s.Endpoints(group) {
+			op := Operation{
+				Group:             group,
+				Name:              ep.Name,
+				Method:            ep.Method,
+				Path:              ep.Path,
+				Deprecated:        ep.Op.Deprecated,
+				SpecRequestModel:  specRequest(mi, ep.Op),
+				SpecResponseModel: specResponse(mi, ep.Op),
+			}
+			if c, ok := byPath[strings.ToLower(ep.Path)]; ok {
+				matched[strings.ToLower(ep.Path)] = true
+				op.Implemented = true
+				op.Builder = c.Package + "." + c.Builder
+				op.RequestModel = c.Request
+				op.ResponseModel = c.Response
+				op.QueryParams = c.Query
+				op.Issues = compare(ep, op, c)
+				gs.Implemented++
+			}
+			if len(op.Issues) > 0 {
+				rep.Summary.WithIssues++
+			}
+			gs.Operations++
+			rep.Operations = append(rep.Operations, op)
+		}
+		gs.Coverage = ratio(gs.Implemented, gs.Operations)
+		rep.Summary.Groups[group] = gs
+		rep.Summary.Operations += gs.Operations
+		rep.Summary.Implemented += gs.Implemented
+
+		if mi != nil {
+			for _, def := range mi.Definitions() {
+				if strings.HasPrefix(def, group+"_") && strings.HasSuffix(def, "Request") {
+					if goName := mi.Lookup(def).GoName; !sent[goName] {
+						unused = append(unused, strings.ToLower(group)+"/models."+goName)
+					}
+				}
+			}
+		}
+	}
+	rep.Summary.Coverage = ratio(rep.Summary.Implemented, rep.Summary.Operations)
+	rep.UnusedRequestModels = unused
+
+	for _, c := range calls {
+		if !matched[strings.ToLower(c.Path)] {
+			rep.UnknownBuilders = append(rep.UnknownBuilders, Builder{Builder: c.Package + "." + c.Builder, Method: c.Method, Path: c.Path})
+		}
+	}
+	return rep, nil
+}
+
+func compare(ep swagger.Endpoint, op Operation, c builderCall) []string {
+	var issues []string
+	if c.Method != ep.Method {
+		issues = append(issues, fmt.Sprintf("method mismatch: sdk %s, spec %s", c.Method, ep.Method))
+	}
+
+	specQuery := make(map[string]bool)
+	hasBody := false
+	for _, p := range ep.Op.Parameters {
+		switch p.In {
+		case "query":
+			specQuery[p.Name] = true
+		case "body":
+			hasBody = true
+		}
+	}
+	sdkQuery := make(map[string]bool)
+	for _, k := range c.Query {
+		sdkQuery[k] = true
+	}
+	var missing, unknown []string
+	for _, p := range ep.Op.Parameters {
+		if p.In == "query" && !sdkQuery[p.Name] {
+			missing = append(missing, p.Name)
+		}
+	}
+	for _, k := range c.Query {
+		if !specQuery[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(missing) > 0 {
+		issues = append(issues, "missing query params: "+strings.Join(missing, ", "))
+	}
+	if len(unknown) > 0 {
+		issues = append(issues, "query params not in spec: "+strings.Join(unknown, ", "))
+	}
+
+	switch {
+	case hasBody && !c.HasBody:
+		issues = append(issues, fmt.Sprintf("request model %s is unused: sdk sends no body", op.SpecRequestModel))
+	case !hasBody && c.HasBody:
+		issues = append(issues, "sdk sends a body the spec does not declare")
+	case hasBody && op.SpecRequestModel != "" && op.RequestModel != "" && baseType(op.RequestModel) != op.SpecRequestModel:
+		issues = append(issues, fmt.Sprintf("request model mismatch: sdk %s, spec %s", op.RequestModel, op.SpecRequestModel))
+	}
+
+	if op.SpecResponseModel != "" && op.ResponseModel == "" {
+		issues = append(issues, fmt.Sprintf("response %s is not decoded", op.SpecResponseModel))
+	} else if op.SpecResponseModel != "" && shape(op.ResponseModel) != op.SpecResponseModel {
+		issues = append(issues, fmt.Sprintf("response mismatch: sdk %s, spec %s", op.ResponseModel, op.SpecResponseModel))
+	}
+	return issues
+}
+
+// specRequest returns the Go model name of the body parameter.
+func specRequest(mi *swagger.ModelIndex, op *swagger.Operation) string {
+	for _, p := range op.Parameters {
+		if p.In == "body" {
+			return goName(mi, p.Schema.RefName())
+		}
+	}
+	return ""
+}
+
+// specResponse returns the response shape in Go terms, e.g. []OrderDetails.
+func specResponse(mi *swagger.ModelIndex, op *swagger.Operation) string {
+	for _, code := range []string{"200", "201", "default"} {
+		r := op.Responses[code]
+		if r == nil || r.Schema == nil {
+			continue
+		}
+		if r.Schema.Type == "array" && r.Schema.Items != nil {
+			if def := r.Schema.Items.RefName(); def != "" {
+				return "[]" + goName(mi, def)
+			}
+			return "[]" + r.Schema.Items.Type
+		}
+		if def := r.Schema.RefName(); def != "" {
+			return goName(mi, def)
+		}
+		return r.Schema.Type
+	}
+	return ""
+}
+
+func goName(mi *swagger.ModelIndex, def string) string {
+	if def == "" {
+		return ""
+	}
+	if mi != nil {
+		if m := mi.Lookup(def); m != nil {
+			return m.GoName
+		}
+	}
+	return def
+}
+
+// baseType strips pointers, slices and the package qualifier: []*models.Foo -> Foo.
+func baseType(t string) string {
+	t = strings.TrimLeft(t, "[]*")
+	if i := strings.LastIndexByte(t, '.'); i >= 0 {
+		t = t[i+1:]
+	}
+	return t
+}
+
+// shape keeps slices but drops pointers and qualifiers: []*models.Foo -> []Foo.
+func shape(t string) string {
+	prefix := ""
+	for strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "*") {
+		if strings.HasPrefix(t, "[]") {
+			prefix += "[]"
+			t = t[2:]
+		} else {
+			t = t[1:]
+		}
+	}
+	switch t {
+	case "string", "strfmt.UUID", "strfmt.DateTime":
+		t = "string"
+	case "int32", "int64":
+		t = "integer"
+	case "float32", "float64":
+		t = "number"
+	case "bool":
+		t = "boolean"
+	default:
+		t = baseType(t)
+	}
+	return prefix + t
+}
+
+func groups(s *swagger.Spec) []string {
+	seen := make(map[string]bool)
+	for p := range s.Paths {
+		if rest, ok := strings.CutPrefix(path.Dir(p), "/api/"); ok && rest != "" && !strings.Contains(rest, "/") {
+			seen[rest] = true
+		}
+	}
+	out := make([]string, 0, len(seen))
+	for g := range seen {
+		out = append(out, g)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func loadGroupModels(root, group string) (*swagger.ModelIndex, error) {
+	dir := filepath.Join(root, strings.ToLower(group), "models")
+	if _, err := os.Stat(dir); err != nil {
+		return nil, nil
+	}
+	return swagger.LoadModels(dir)
+}
+
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
+// Regressions lists operations that were implemented and clean in base but are missing or
+// have new issues now, and operations that disappeared from the spec.
+func (r *Report) Regressions(base *Report) []string {
+	current := make(map[string]Operation, len(r.Operations))
+	for _, op := range r.Operations {
+		current[op.key()] = op
+	}
+	var out []string
+	for _, old := range base.Operations {
+		op, ok := current[old.key()]
+		switch {
+		case !ok:
+			out = append(out, fmt.Sprintf("%s %s: removed from the spec", old.Method, old.Path))
+		case old.Implemented && !op.Implemented:
+			out = append(out, fmt.Sprintf("%s %s: builder no longer found", op.Method, op.Path))
+		default:
+			known := make(map[string]bool)
+			for _, issue := range old.Issues {
+				known[issue] = true
+			}
+			for _, issue := range op.Issues {
+				if !known[issue] {
+					out = append(out, fmt.Sprintf("%s %s: %s", op.Method, op.Path, issue))
+				}
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,104 @@
+package coverage
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func operation(t *testing.T, rep *Report, name string) Operation {
+	t.Helper()
+	for _, op := range rep.Operations {
+		if op.Name == name {
+			return op
+		}
+	}
+	t.Fatalf("operation %s not in report", name)
+	return Operation{}
+}
+
+func TestAnalyze(t *testing.T) {
+	// testdata/sdk mirrors the SDK layout with a fixed set of builders, so the expected
+	// totals do not move whenever a real builder is added.
+	rep, err := Analyze("testdata/spec.json", "testdata/sdk")
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	t.Run("should count implemented operations per group", func(t *testing.T) {
+		if rep.Summary.Operations != 29 || rep.Summary.Implemented != 5 || rep.Summary.WithIssues != 2 {
+			t.Errorf("unexpected summary %+v", rep.Summary)
+		}
+		if g := rep.Summary.Groups["Inventory"]; g.Coverage != 1 {
+			t.Errorf("Expected full inventory coverage, got %+v", g)
+		}
+	})
+
+	t.Run("should report builder and models of a clean operation", func(t *testing.T) {
+		op := operation(t, rep, "GetOrdersById")
+		if op.Builder != "orders.GetOrdersByIdRequestBuilder" {
+			t.Errorf("unexpected builder %q", op.Builder)
+		}
+		if op.RequestModel != "*models.OrdersGetOrdersByIDRequest" || op.ResponseModel != "[]models.OrderDetails" {
+			t.Errorf("unexpected models %q %q", op.RequestModel, op.ResponseModel)
+		}
+		if len(op.Issues) != 0 {
+			t.Errorf("Expected no issues, got %v", op.Issues)
+		}
+	})
+
+	t.Run("should report a spec operation without a builder as unimplemented", func(t *testing.T) {
+		op := operation(t, rep, "MergeOrders")
+		if op.Implemented || op.Builder != "" || op.ResponseModel != "" {
+			t.Errorf("Expected MergeOrders to be unimplemented, got %+v", op)
+		}
+		if op.SpecRequestModel != "OrdersMergeOrdersRequest" {
+			t.Errorf("Expected the spec request model to be reported, got %q", op.SpecRequestModel)
+		}
+	})
+
+	t.Run("should flag method, query and request model mismatches", func(t *testing.T) {
+		op := operation(t, rep, "GetOrderDetailsByNumOrderId")
+		want := []string{"method mismatch: sdk GET, spec POST", "missing query params: fulfilmentCenter"}
+		if !slices.Equal(op.Issues, want) {
+			t.Errorf("Expected %v, got %v", want, op.Issues)
+		}
+		if !slices.Equal(op.QueryParams, []string{"OrderId"}) {
+			t.Errorf("unexpected query params %v", op.QueryParams)
+		}
+		open := operation(t, rep, "GetOpenOrders")
+		if len(open.Issues) != 1 || !strings.HasPrefix(open.Issues[0], "request model mismatch") {
+			t.Errorf("Expected request model mismatch, got %v", open.Issues)
+		}
+	})
+
+	t.Run("should list unused request models and unknown builders", func(t *testing.T) {
+		want := []string{"orders/models.OrdersGetAllOpenOrdersRequest", "orders/models.OrdersMergeOrdersRequest"}
+		if !slices.Equal(rep.UnusedRequestModels, want) {
+			t.Errorf("Expected %v, got %v", want, rep.UnusedRequestModels)
+		}
+		if op := operation(t, rep, "LockOrder"); op.Builder != "orders.LockOrderRequestBuilder" || len(op.Issues) != 0 {
+			t.Errorf("unexpected LockOrder %+v", op)
+		}
+		if len(rep.UnknownBuilders) != 1 || rep.UnknownBuilders[0].Path != "/api/ProcessedOrders/SearchProcessedOrders" {
+			t.Errorf("unexpected unknown builders %+v", rep.UnknownBuilders)
+		}
+	})
+
+	t.Run("should detect regressions against a baseline", func(t *testing.T) {
+		base := &Report{Operations: []Operation{
+			{Method: "POST", Path: "/api/Orders/GetOrdersById", Implemented: true},
+			{Method: "POST", Path: "/api/Orders/LockOrder", Implemented: true},
+			{Method: "POST", Path: "/api/Orders/MergeOrders", Implemented: true},
+			{Method: "POST", Path: "/api/Orders/GetOrderDetailsByNumOrderId", Implemented: true, Issues: []string{"method mismatch: sdk GET, spec POST"}},
+		}}
+		got := rep.Regressions(base)
+		want := []string{
+			"POST /api/Orders/MergeOrders: builder no longer found",
+			"POST /api/Orders/GetOrderDetailsByNumOrderId: missing query params: fulfilmentCenter",
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+}
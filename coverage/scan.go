@@ -0,0 +1,339 @@
+package coverage
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// builderCall is one DoJSON call found in the SDK source.
+type builderCall struct {
+	Package string
+	Builder string
+	Method  string
+	Path    string
+	Query   []string
+	// Request and Response are the Go types as written, e.g. *models.OrdersGetOrdersByIDRequest.
+	Request  string
+	Response string
+	HasBody  bool
+}
+
+// scanSDK finds DoJSON calls in every request group package under root, i.e. every
+// directory next to a models directory.
+func scanSDK(root string) ([]builderCall, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	var out []builderCall
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		dir := filepath.Join(root, e.Name())
+		if st, err := os.Stat(filepath.Join(dir, "models")); err != nil || !st.IsDir() {
+			continue
+		}
+		calls, err := scanPackage(dir)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, calls...)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, nil
+}
+
+type pkgIndex struct {
+	name    string
+	structs map[string]*ast.StructType
+	// methods maps receiver type and method name to its declaration.
+	methods map[string]map[string]*ast.FuncDecl
+	consts  map[string]string
+}
+
+func scanPackage(dir string) ([]builderCall, error) {
+	fset := token.NewFileSet()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	idx := &pkgIndex{
+		structs: make(map[string]*ast.StructType),
+		methods: make(map[string]map[string]*ast.FuncDecl),
+		consts:  make(map[string]string),
+	}
+	var funcs []*ast.FuncDecl
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") || strings.HasSuffix(e.Name(), "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, e.Name()), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		idx.name = f.Name.Name
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, s := range d.Specs {
+					switch s := s.(type) {
+					case *ast.TypeSpec:
+						if st, ok := s.Type.(*ast.StructType); ok {
+							idx.structs[s.Name.Name] = st
+						}
+					case *ast.ValueSpec:
+						for i, name := range s.Names {
+							if i < len(s.Values) {
+								if v, ok := stringLit(s.Values[i]); ok {
+									idx.consts[name.Name] = v
+								}
+							}
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				funcs = append(funcs, d)
+				if recv := receiverType(d); recv != "" {
+					if idx.methods[recv] == nil {
+						idx.methods[recv] = make(map[string]*ast.FuncDecl)
+					}
+					idx.methods[recv][d.Name.Name] = d
+				}
+			}
+		}
+	}
+
+	var out []builderCall
+	for _, fn := range funcs {
+		if fn.Body == nil {
+			continue
+		}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 6 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "DoJSON" {
+				return true
+			}
+			path, ok := idx.resolveString(fn, call.Args[2])
+			if !ok || !strings.HasPrefix(path, "/api/") {
+				return true
+			}
+			bc := builderCall{
+				Package: idx.name,
+				Builder: receiverType(fn),
+				Method:  httpMethod(call.Args[1]),
+				Path:    path,
+			}
+			if !isNil(call.Args[3]) {
+				bc.Query = idx.queryKeys(bc.Builder, fn)
+			}
+			if !isNil(call.Args[4]) {
+				bc.HasBody = true
+				bc.Request = idx.exprType(fn, call.Args[4])
+			}
+			if u, ok := call.Args[5].(*ast.UnaryExpr); ok && u.Op == token.AND {
+				bc.Response = idx.exprType(fn, u.X)
+			}
+			out = append(out, bc)
+			return true
+		})
+	}
+	return out, nil
+}
+
+func receiverType(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if id, ok := expr.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
+func httpMethod(expr ast.Expr) string {
+	if sel, ok := expr.(*ast.SelectorExpr); ok {
+		return strings.ToUpper(strings.TrimPrefix(sel.Sel.Name, "Method"))
+	}
+	if v, ok := stringLit(expr); ok {
+		return strings.ToUpper(v)
+	}
+	return ""
+}
+
+func isNil(expr ast.Expr) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && id.Name == "nil"
+}
+
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	v, err := strconv.Unquote(lit.Value)
+	return v, err == nil
+}
+
+// resolveString follows an identifier to a string assigned in fn or a package constant.
+func (idx *pkgIndex) resolveString(fn *ast.FuncDecl, expr ast.Expr) (string, bool) {
+	if v, ok := stringLit(expr); ok {
+		return v, true
+	}
+	id, ok := expr.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	if rhs := assignedTo(fn, id.Name); rhs != nil {
+		return stringLit(rhs)
+	}
+	v, ok := idx.consts[id.Name]
+	return v, ok
+}
+
+// assignedTo returns the single-value expression assigned to name in fn.
+func assignedTo(fn *ast.FuncDecl, name string) ast.Expr {
+	var out ast.Expr
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if as, ok := n.(*ast.AssignStmt); ok && len(as.Lhs) == len(as.Rhs) {
+			for i, lhs := range as.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok && id.Name == name {
+					out = as.Rhs[i]
+				}
+			}
+		}
+		return out == nil
+	})
+	return out
+}
+
+// exprType returns the type of a DoJSON argument: a local var, a value returned by a
+// builder method (req, err := b.build()) or a builder field (b.data).
+func (idx *pkgIndex) exprType(fn *ast.FuncDecl, expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		var out string
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			switch s := n.(type) {
+			case *ast.ValueSpec:
+				for _, name := range s.Names {
+					if name.Name == e.Name && s.Type != nil {
+						out = render(s.Type)
+					}
+				}
+			case *ast.AssignStmt:
+				for i, lhs := range s.Lhs {
+					id, ok := lhs.(*ast.Ident)
+					if !ok || id.Name != e.Name || len(s.Rhs) != 1 {
+						continue
+					}
+					if call, ok := s.Rhs[0].(*ast.CallExpr); ok {
+						out = idx.resultType(fn, call, i)
+					}
+				}
+			}
+			return out == ""
+		})
+		return out
+	case *ast.SelectorExpr:
+		if st := idx.structs[receiverType(fn)]; st != nil {
+			for _, f := range st.Fields.List {
+				for _, name := range f.Names {
+					if name.Name == e.Sel.Name {
+						return render(f.Type)
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func (idx *pkgIndex) resultType(fn *ast.FuncDecl, call *ast.CallExpr, i int) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	m := idx.methods[receiverType(fn)][sel.Sel.Name]
+	if m == nil || m.Type.Results == nil {
+		return ""
+	}
+	var types []ast.Expr
+	for _, r := range m.Type.Results.List {
+		n := max(len(r.Names), 1)
+		for range n {
+			types = append(types, r.Type)
+		}
+	}
+	if i >= len(types) {
+		return ""
+	}
+	return render(types[i])
+}
+
+// queryKeys collects literal keys passed to query.Set/Add in fn and in every method of the builder.
+func (idx *pkgIndex) queryKeys(builder string, fn *ast.FuncDecl) []string {
+	seen := make(map[string]bool)
+	visit := func(decl *ast.FuncDecl) {
+		if decl.Body == nil {
+			return
+		}
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 2 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || (sel.Sel.Name != "Set" && sel.Sel.Name != "Add") || !isQueryValues(sel.X) {
+				return true
+			}
+			if key, ok := stringLit(call.Args[0]); ok {
+				seen[key] = true
+			}
+			return true
+		})
+	}
+	visit(fn)
+	for _, m := range idx.methods[builder] {
+		if m != fn {
+			visit(m)
+		}
+	}
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func isQueryValues(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name == "query" || e.Name == "q" || e.Name == "values"
+	case *ast.SelectorExpr:
+		return e.Sel.Name == "query"
+	}
+	return false
+}
+
+func render(expr ast.Expr) string {
+	var b strings.Builder
+	printer.Fprint(&b, token.NewFileSet(), expr)
+	return b.String()
+}
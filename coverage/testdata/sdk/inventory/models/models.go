@@ -0,0 +1,9 @@
+package models
+
+import "github.com/go-openapi/strfmt"
+
+// swagger:model StockLocation
+type StockLocation struct {
+	StockLocationID strfmt.UUID `json:"StockLocationId,omitempty"`
+	LocationName    string      `json:"LocationName,omitempty"`
+}
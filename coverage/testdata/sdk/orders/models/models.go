@@ -0,0 +1,48 @@
+package models
+
+import "github.com/go-openapi/strfmt"
+
+// swagger:model Orders_GetOrdersByIdRequest
+type OrdersGetOrdersByIDRequest struct {
+	PkOrderIds []strfmt.UUID `json:"pkOrderIds"`
+}
+
+// swagger:model OrderDetails
+type OrderDetails struct {
+	OrderID    strfmt.UUID `json:"OrderId,omitempty"`
+	NumOrderID int32       `json:"NumOrderId,omitempty"`
+}
+
+// swagger:model Orders_GetAllOpenOrdersRequest
+type OrdersGetAllOpenOrdersRequest struct {
+	EntriesPerPage int32 `json:"entriesPerPage,omitempty"`
+	PageNumber     int32 `json:"pageNumber,omitempty"`
+}
+
+// swagger:model Orders_GetOpenOrdersRequest
+type OrdersGetOpenOrdersRequest struct {
+	EntriesPerPage int32 `json:"EntriesPerPage,omitempty"`
+	PageNumber     int32 `json:"PageNumber,omitempty"`
+}
+
+// swagger:model OpenOrder
+type OpenOrder struct {
+	OrderID strfmt.UUID `json:"OrderId,omitempty"`
+}
+
+// swagger:model GenericPagedResult_OpenOrder
+type GenericPagedResultOpenOrder struct {
+	Data         []*OpenOrder `json:"Data"`
+	TotalEntries int32        `json:"TotalEntries,omitempty"`
+}
+
+// swagger:model Orders_LockOrderRequest
+type OrdersLockOrderRequest struct {
+	LockOrder bool          `json:"lockOrder,omitempty"`
+	OrderIds  []strfmt.UUID `json:"orderIds"`
+}
+
+// swagger:model Orders_MergeOrdersRequest
+type OrdersMergeOrdersRequest struct {
+	OrdersToMerge []strfmt.UUID `json:"ordersToMerge"`
+}
@@ -0,0 +1,88 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	lw_api "github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+// GetOrdersByIdRequestBuilder matches the spec exactly.
+type GetOrdersByIdRequestBuilder struct {
+	ctx    context.Context
+	client lw_api.MakeRequest
+	data   *models.OrdersGetOrdersByIDRequest
+}
+
+func (b *GetOrdersByIdRequestBuilder) build() (*models.OrdersGetOrdersByIDRequest, error) {
+	if len(b.data.PkOrderIds) == 0 {
+		return nil, errors.New("pkOrderIds is required")
+	}
+	return b.data, nil
+}
+
+func (b *GetOrdersByIdRequestBuilder) Do() ([]models.OrderDetails, error) {
+	req, err := b.build()
+	if err != nil {
+		return nil, err
+	}
+	var out []models.OrderDetails
+	if err := b.client.DoJSON(b.ctx, http.MethodPost, "/api/Orders/GetOrdersById", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOrderDetailsByNumOrderIdRequestBuilder uses GET where the spec says POST and
+// leaves out the fulfilmentCenter query parameter.
+type GetOrderDetailsByNumOrderIdRequestBuilder struct {
+	ctx        context.Context
+	client     lw_api.MakeRequest
+	numOrderID int32
+}
+
+func (b *GetOrderDetailsByNumOrderIdRequestBuilder) query() url.Values {
+	query := url.Values{}
+	query.Set("OrderId", strconv.FormatInt(int64(b.numOrderID), 10))
+	return query
+}
+
+func (b *GetOrderDetailsByNumOrderIdRequestBuilder) Do() (*models.OrderDetails, error) {
+	var out models.OrderDetails
+	if err := b.client.DoJSON(b.ctx, http.MethodGet, "/api/Orders/GetOrderDetailsByNumOrderId", b.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetOpenOrdersRequestBuilder sends a different request model than the spec declares.
+type GetOpenOrdersRequestBuilder struct {
+	ctx    context.Context
+	client lw_api.MakeRequest
+	data   *models.OrdersGetOpenOrdersRequest
+}
+
+func (b *GetOpenOrdersRequestBuilder) Do() (*models.GenericPagedResultOpenOrder, error) {
+	var out models.GenericPagedResultOpenOrder
+	if err := b.client.DoJSON(b.ctx, http.MethodPost, "/api/Orders/GetOpenOrders", nil, b.data, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+const lockOrderPath = "/api/Orders/LockOrder"
+
+// LockOrderRequestBuilder is a void call whose path is a package constant.
+type LockOrderRequestBuilder struct {
+	ctx    context.Context
+	client lw_api.MakeRequest
+	data   *models.OrdersLockOrderRequest
+}
+
+func (b *LockOrderRequestBuilder) Do() error {
+	return b.client.DoJSON(b.ctx, http.MethodPost, lockOrderPath, nil, b.data, nil)
+}
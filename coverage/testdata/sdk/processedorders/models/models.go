@@ -0,0 +1,11 @@
+package models
+
+// swagger:model SearchProcessedOrdersRequest
+type SearchProcessedOrdersRequest struct {
+	SearchTerm string `json:"SearchTerm,omitempty"`
+}
+
+// swagger:model SearchProcessedOrdersResponse
+type SearchProcessedOrdersResponse struct {
+	TotalEntries int32 `json:"TotalEntries,omitempty"`
+}
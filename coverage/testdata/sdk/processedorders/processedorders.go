@@ -0,0 +1,24 @@
+package processedorders
+
+import (
+	"context"
+	"net/http"
+
+	lw_api "github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/processedorders/models"
+)
+
+// SearchProcessedOrdersRequestBuilder calls a path the spec does not list.
+type SearchProcessedOrdersRequestBuilder struct {
+	ctx    context.Context
+	client lw_api.MakeRequest
+	data   *models.SearchProcessedOrdersRequest
+}
+
+func (b *SearchProcessedOrdersRequestBuilder) Do() (*models.SearchProcessedOrdersResponse, error) {
+	var out models.SearchProcessedOrdersResponse
+	if err := b.client.DoJSON(b.ctx, http.MethodPost, "/api/ProcessedOrders/SearchProcessedOrders", nil, b.data, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
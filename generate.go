@@ -0,0 +1,3 @@
+package lw_api
+
+//go:generate go run ./cmd/lwgen -spec third_party/linnworks.json -group Orders -allowlist orders/lwgen_endpoints.txt
@@ -0,0 +1,151 @@
+package swagger
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ModelType is a go-swagger model struct as declared in <group>/models.
+type ModelType struct {
+	GoName string
+	Fields []ModelField
+}
+
+type ModelField struct {
+	GoName   string
+	JSONName string
+	// Type is qualified for use outside the models package, e.g. []*models.OrderItem.
+	Type string
+	Doc  string
+}
+
+// ModelIndex maps swagger definition names (from the "swagger:model" comment) to Go types.
+type ModelIndex struct {
+	byDefinition map[string]*ModelType
+	// names holds every exported type declared in the package, including non-struct ones.
+	names map[string]bool
+}
+
+func (m *ModelIndex) Lookup(definition string) *ModelType {
+	if t, ok := m.byDefinition[definition]; ok {
+		return t
+	}
+	return nil
+}
+
+// Definitions returns every indexed definition name, sorted.
+func (m *ModelIndex) Definitions() []string {
+	out := make([]string, 0, len(m.byDefinition))
+	for name := range m.byDefinition {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// LoadModels indexes the go-swagger models in dir.
+func LoadModels(dir string) (*ModelIndex, error) {
+	fset := token.NewFileSet()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") || strings.HasSuffix(e.Name(), "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, e.Name()), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	idx := &ModelIndex{
+		byDefinition: make(map[string]*ModelType),
+		names:        make(map[string]bool),
+	}
+	type pending struct {
+		spec *ast.TypeSpec
+		doc  *ast.CommentGroup
+	}
+	var structs []pending
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, s := range gd.Specs {
+				ts := s.(*ast.TypeSpec)
+				idx.names[ts.Name.Name] = true
+				doc := ts.Doc
+				if doc == nil {
+					doc = gd.Doc
+				}
+				structs = append(structs, pending{ts, doc})
+			}
+		}
+	}
+
+	for _, p := range structs {
+		mt := &ModelType{GoName: p.spec.Name.Name}
+		if st, ok := p.spec.Type.(*ast.StructType); ok {
+			for _, field := range st.Fields.List {
+				if len(field.Names) != 1 || field.Tag == nil {
+					continue
+				}
+				tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
+				jsonName, _, _ := strings.Cut(tag.Get("json"), ",")
+				if jsonName == "" || jsonName == "-" {
+					continue
+				}
+				mt.Fields = append(mt.Fields, ModelField{
+					GoName:   field.Names[0].Name,
+					JSONName: jsonName,
+					Type:     idx.qualify(field.Type),
+					Doc:      strings.TrimSpace(field.Doc.Text()),
+				})
+			}
+		}
+		definition := mt.GoName
+		if p.doc != nil {
+			for _, c := range p.doc.List {
+				if name, ok := strings.CutPrefix(c.Text, "// swagger:model "); ok {
+					definition = strings.TrimSpace(name)
+				}
+			}
+		}
+		idx.byDefinition[definition] = mt
+	}
+	return idx, nil
+}
+
+// qualify renders a field type as seen from the builder package.
+func (m *ModelIndex) qualify(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if m.names[t.Name] {
+			return "models." + t.Name
+		}
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + m.qualify(t.X)
+	case *ast.ArrayType:
+		return "[]" + m.qualify(t.Elt)
+	case *ast.MapType:
+		return "map[" + m.qualify(t.Key) + "]" + m.qualify(t.Value)
+	case *ast.SelectorExpr:
+		return m.qualify(t.X) + "." + t.Sel.Name
+	case *ast.InterfaceType:
+		return "any"
+	}
+	return "any"
+}
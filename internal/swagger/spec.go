@@ -0,0 +1,177 @@
+// Package swagger reads the subset of the Linnworks Swagger 2.0 spec and the go-swagger
+// models that lwgen and lwcoverage need.
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+type Spec struct {
+	Paths       map[string]*PathItem `json:"paths"`
+	Definitions map[string]*Schema   `json:"definitions"`
+}
+
+type PathItem struct {
+	Get    *Operation `json:"get"`
+	Post   *Operation `json:"post"`
+	Put    *Operation `json:"put"`
+	Delete *Operation `json:"delete"`
+	Patch  *Operation `json:"patch"`
+}
+
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Tags        []string             `json:"tags"`
+	Summary     string               `json:"summary"`
+	Description string               `json:"description"`
+	Deprecated  bool                 `json:"deprecated"`
+	Parameters  []*Parameter         `json:"parameters"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Type        string  `json:"type"`
+	Format      string  `json:"format"`
+	Schema      *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string  `json:"description"`
+	Schema      *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref        string             `json:"$ref"`
+	Type       string             `json:"type"`
+	Format     string             `json:"format"`
+	Items      *Schema            `json:"items"`
+	Required   []string           `json:"required"`
+	Properties map[string]*Schema `json:"properties"`
+}
+
+// RefName returns the definition name of a local $ref.
+func (s *Schema) RefName() string {
+	if s == nil {
+		return ""
+	}
+	return strings.TrimPrefix(s.Ref, "#/definitions/")
+}
+
+// Endpoint is one operation of a request group, e.g. POST /api/Orders/LockOrder.
+type Endpoint struct {
+	Group   string
+	Name    string
+	Method  string
+	Path    string
+	Summary string
+	Op      *Operation
+}
+
+func (p *PathItem) Operations() map[string]*Operation {
+	out := make(map[string]*Operation)
+	for method, op := range map[string]*Operation{
+		http.MethodGet: p.Get, http.MethodPost: p.Post, http.MethodPut: p.Put,
+		http.MethodDelete: p.Delete, http.MethodPatch: p.Patch,
+	} {
+		if op != nil {
+			out[method] = op
+		}
+	}
+	return out
+}
+
+// Endpoints lists the operations under /api/<group>/ sorted by name.
+func (s *Spec) Endpoints(group string) []Endpoint {
+	prefix := "/api/" + group + "/"
+	var out []Endpoint
+	for p, item := range s.Paths {
+		if item == nil || !strings.EqualFold(path.Dir(p)+"/", prefix) {
+			continue
+		}
+		for method, op := range item.Operations() {
+			summary := op.Summary
+			if summary == "" {
+				summary = op.Description
+			}
+			out = append(out, Endpoint{
+				Group:   group,
+				Name:    path.Base(p),
+				Method:  method,
+				Path:    p,
+				Summary: summary,
+				Op:      op,
+			})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].Method < out[j].Method
+	})
+	return out
+}
+
+// Load reads a JSON or YAML spec from a file or an http(s) URL.
+func Load(src string) (*Spec, error) {
+	var data []byte
+	var err error
+	if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") {
+		data, err = fetch(src)
+	} else {
+		data, err = os.ReadFile(src)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse decodes a JSON or YAML spec.
+func Parse(data []byte) (*Spec, error) {
+	if !json.Valid(data) {
+		var v any
+		if err := yaml.Unmarshal(data, &v); err != nil {
+			return nil, fmt.Errorf("spec is neither json nor yaml: %w", err)
+		}
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	var s Spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if len(s.Paths) == 0 {
+		return nil, fmt.Errorf("spec has no paths")
+	}
+	return &s, nil
+}
+
+func fetch(u string) ([]byte, error) {
+	hc := &http.Client{Timeout: time.Minute}
+	resp, err := hc.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch %s: %s", u, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,10 @@
+package inventory
+
+import "context"
+
+// InventoryAPI is the inventory branch of the client.
+type InventoryAPI interface {
+	GetStockLocations(ctx context.Context) *GetStockLocationsRequestBuilder
+}
+
+var _ InventoryAPI = (*Inventory)(nil)
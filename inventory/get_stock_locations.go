@@ -12,6 +12,25 @@ import (
 type GetStockLocationsRequestBuilder struct {
 	ctx    context.Context
 	client lw_api.MakeRequest
+	opts   []lw_api.CallOption
+}
+
+// RetryPolicy overrides the client retry policy for this call.
+func (b *GetStockLocationsRequestBuilder) RetryPolicy(policy lw_api.RetryPolicy) *GetStockLocationsRequestBuilder {
+	return b.WithOptions(lw_api.CallRetryPolicy(policy))
+}
+
+// WithOptions applies per-call options such as a timeout, extra headers or a rate limit priority.
+func (b *GetStockLocationsRequestBuilder) WithOptions(opts ...lw_api.CallOption) *GetStockLocationsRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	b.opts = append(b.opts, opts...)
+	return b
+}
+
+func (b *GetStockLocationsRequestBuilder) requestContext(ctx context.Context) context.Context {
+	return lw_api.WithCallOptions(ctx, b.opts...)
 }
 
 func (b *GetStockLocationsRequestBuilder) Do() ([]models.StockLocation, error) {
@@ -20,8 +39,16 @@ func (b *GetStockLocationsRequestBuilder) Do() ([]models.StockLocation, error) {
 	}
 
 	var out []models.StockLocation
-	if err := b.client.DoJSON(b.ctx, http.MethodGet, "/api/Inventory/GetStockLocations", nil, nil, &out); err != nil {
+	if err := b.client.DoJSON(b.requestContext(b.ctx), http.MethodGet, "/api/Inventory/GetStockLocations", nil, nil, &out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
+
+// DoRaw sends the request and returns the undecoded response with its status and headers.
+func (b *GetStockLocationsRequestBuilder) DoRaw() (*lw_api.Response, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	return lw_api.DoRaw(b.requestContext(b.ctx), b.client, http.MethodGet, "/api/Inventory/GetStockLocations", nil, nil)
+}
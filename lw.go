@@ -7,6 +7,8 @@ import (
 	"github.com/MMC-BK/lw-api/inventory"
 	"github.com/MMC-BK/lw-api/orders"
 	"github.com/MMC-BK/lw-api/processedorders"
+	"log/slog"
+	"net/http"
 	"net/url"
 	"time"
 )
@@ -16,19 +18,63 @@ type LinnworksAPI struct {
 	*orders.Orders
 	*processedorders.ProcessedOrders
 	*inventory.Inventory
+
+	tokenSource  *auth.RefreshTokenSource
+	authCounters *client2.AuthCounters
+}
+
+// API is every branch of LinnworksAPI. Consumer code can accept it and take an lwmock.API in tests.
+type API interface {
+	orders.OrdersAPI
+	processedorders.ProcessedOrdersAPI
+	inventory.InventoryAPI
+}
+
+var _ API = (*LinnworksAPI)(nil)
+
+// AuthCounters reports how many 401s triggered a token refresh and a re-signed retry.
+func (api *LinnworksAPI) AuthCounters() *client2.AuthCounters {
+	if api == nil {
+		return nil
+	}
+	return api.authCounters
+}
+
+// Close stops background work such as proactive token refresh.
+func (api *LinnworksAPI) Close() error {
+	if api == nil || api.tokenSource == nil {
+		return nil
+	}
+	return api.tokenSource.Close()
 }
 
 type LinnworksAPIBuilder struct {
 	baseURL      string
+	authURL      string
+	discoverURL  bool
 	appID        string
 	appSecret    string
 	token        string
 	refreshToken string
+	retryPolicy  client2.RetryPolicy
+	tokenStore   auth.TokenStore
+	refreshLead  time.Duration
+	transport    http.RoundTripper
+	rateLimit    int
+	ratePer      time.Duration
+	middlewares  []client2.RTMiddleware
+	logger       *slog.Logger
+	strict       *client2.StrictDecoding
 	err          []error
 }
 
 func NewLinnworksAPIBuilder() *LinnworksAPIBuilder {
-	return &LinnworksAPIBuilder{err: make([]error, 0)}
+	return &LinnworksAPIBuilder{
+		retryPolicy: client2.DefaultRetryPolicy(),
+		rateLimit:   10,
+		ratePer:     time.Second,
+		err:         make([]error, 0),
+	}
 }
 
 func (b *LinnworksAPIBuilder) BaseURL(base string) *LinnworksAPIBuilder {
@@ -47,6 +93,34 @@ func (b *LinnworksAPIBuilder) BaseURL(base string) *LinnworksAPIBuilder {
 	return b
 }
 
+// BaseURLFromSession takes the API host from the Server field of the AuthorizeByApplication
+// session and follows it when a refresh returns a different host. Mutually exclusive with BaseURL.
+func (b *LinnworksAPIBuilder) BaseURLFromSession() *LinnworksAPIBuilder {
+	if b == nil {
+		return nil
+	}
+	b.discoverURL = true
+	return b
+}
+
+// AuthURL overrides the AuthorizeByApplication host, https://api.linnworks.net by default.
+func (b *LinnworksAPIBuilder) AuthURL(authURL string) *LinnworksAPIBuilder {
+	if b == nil {
+		return nil
+	}
+	u, err := url.Parse(authURL)
+	if err != nil {
+		b.err = append(b.err, err)
+		return b
+	}
+	if u.Scheme == "" || u.Host == "" {
+		b.err = append(b.err, errors.New("authURL must be an absolute url"))
+		return b
+	}
+	b.authURL = authURL
+	return b
+}
+
 func (b *LinnworksAPIBuilder) Token(token string) *LinnworksAPIBuilder {
 	if b == nil {
 		return nil
@@ -91,6 +165,108 @@ func (b *LinnworksAPIBuilder) RefreshToken(refresh string) *LinnworksAPIBuilder
 	return b
 }
 
+func (b *LinnworksAPIBuilder) RetryPolicy(policy client2.RetryPolicy) *LinnworksAPIBuilder {
+	if b == nil {
+		return nil
+	}
+	if policy.MaxRetries < 0 {
+		b.err = append(b.err, errors.New("retry policy MaxRetries must not be negative"))
+		return b
+	}
+	b.retryPolicy = policy
+	return b
+}
+
+// TokenStore persists the session token so restarts and sibling processes reuse it.
+func (b *LinnworksAPIBuilder) TokenStore(store auth.TokenStore) *LinnworksAPIBuilder {
+	if b == nil {
+		return nil
+	}
+	if store == nil {
+		b.err = append(b.err, errors.New("tokenStore cannot be nil"))
+		return b
+	}
+	b.tokenStore = store
+	return b
+}
+
+// ProactiveRefresh refreshes the session in the background lead before it expires.
+// Call LinnworksAPI.Close to stop it.
+func (b *LinnworksAPIBuilder) ProactiveRefresh(lead time.Duration) *LinnworksAPIBuilder {
+	if b == nil {
+		return nil
+	}
+	if lead <= 0 {
+		b.err = append(b.err, errors.New("proactive refresh lead must be greater than 0"))
+		return b
+	}
+	b.refreshLead = lead
+	return b
+}
+
+// RateLimit allows rps requests per period for this client, 10 per second by default.
+func (b *LinnworksAPIBuilder) RateLimit(rps int, per time.Duration) *LinnworksAPIBuilder {
+	if b == nil {
+		return nil
+	}
+	if rps <= 0 || per <= 0 {
+		b.err = append(b.err, errors.New("rate limit must be greater than 0"))
+		return b
+	}
+	b.rateLimit, b.ratePer = rps, per
+	return b
+}
+
+// Logger enables per-attempt request logging with secrets and customer PII redacted.
+// Bodies are logged when the logger has Debug enabled.
+func (b *LinnworksAPIBuilder) Logger(logger *slog.Logger) *LinnworksAPIBuilder {
+	if b == nil {
+		return nil
+	}
+	if logger == nil {
+		b.err = append(b.err, errors.New("logger cannot be nil"))
+		return b
+	}
+	b.logger = logger
+	return b
+}
+
+// StrictDecoding compares every response with its model and reports unknown fields and type
+// mismatches to strict.OnDrift. Calls only fail on drift when strict.Fail is set.
+func (b *LinnworksAPIBuilder) StrictDecoding(strict client2.StrictDecoding) *LinnworksAPIBuilder {
+	if b == nil {
+		return nil
+	}
+	if strict.OnDrift == nil && !strict.Fail {
+		b.err = append(b.err, errors.New("strict decoding needs OnDrift or Fail"))
+		return b
+	}
+	b.strict = &strict
+	return b
+}
+
+// Transport sets the base transport for API and auth calls, e.g. to share connections between clients.
+func (b *LinnworksAPIBuilder) Transport(rt http.RoundTripper) *LinnworksAPIBuilder {
+	if b == nil {
+		return nil
+	}
+	if rt == nil {
+		b.err = append(b.err, errors.New("transport cannot be nil"))
+		return b
+	}
+	b.transport = rt
+	return b
+}
+
+// Middleware adds transport middlewares outside the built-in rate limit, retry and auth chain.
+func (b *LinnworksAPIBuilder) Middleware(mws ...client2.RTMiddleware) *LinnworksAPIBuilder {
+	if b == nil {
+		return nil
+	}
+	b.middlewares = append(b.middlewares, mws...)
+	return b
+}
+
 func (b *LinnworksAPIBuilder) Build() (*LinnworksAPI, error) {
 	if b == nil {
 		return nil, errors.New("builder is nil")
@@ -110,18 +286,48 @@ func (b *LinnworksAPIBuilder) Build() (*LinnworksAPI, error) {
 		RefreshToken: b.refreshToken,
 	}
 
-	ts := auth.NewRefreshTokenSource(creds)
-	authAPI := auth.NewSimpleAuth(ts)
+	var tsOpts []auth.Option
+	if b.authURL != "" {
+		tsOpts = append(tsOpts, auth.WithAuthURL(b.authURL))
+	}
+	if b.tokenStore != nil {
+		tsOpts = append(tsOpts, auth.WithTokenStore(b.tokenStore))
+	}
+	if b.refreshLead > 0 {
+		tsOpts = append(tsOpts, auth.WithProactiveRefresh(b.refreshLead))
+	}
+	if b.transport != nil {
+		tsOpts = append(tsOpts, auth.WithHTTPClient(&http.Client{Timeout: 30 * time.Second, Transport: b.transport}))
+	}
+	ts, err := auth.NewTokenSource(creds, tsOpts...)
+	if err != nil {
+		return nil, err
+	}
+	counters := &client2.AuthCounters{}
+
+	baseURLOpt := client2.WithBaseURL(b.baseURL)
+	if b.discoverURL {
+		baseURLOpt = client2.WithBaseURLSource(ts.Server)
+	}
 
-	c, err := client2.NewClient(
-		client2.WithBaseURL(b.baseURL),
-		client2.WithTransportChain(
-			client2.RateLimitMiddleware(10, time.Second),
-			client2.RetryMiddleware(2, 200*time.Millisecond),
-			client2.AuthMiddleware(authAPI),
-			client2.AutoRefreshOn401(ts),
-		),
+	clientOpts := []client2.Option{baseURLOpt}
+	if b.strict != nil {
+		clientOpts = append(clientOpts, client2.WithStrictDecoding(*b.strict))
+	}
+	if b.transport != nil {
+		clientOpts = append(clientOpts, client2.WithTransport(b.transport))
+	}
+	chain := append(append([]client2.RTMiddleware(nil), b.middlewares...),
+		client2.RateLimitMiddleware(b.rateLimit, b.ratePer),
+		client2.RetryPolicyMiddleware(b.retryPolicy),
 	)
+	if b.logger != nil {
+		chain = append(chain, client2.LoggingMiddleware(b.logger))
+	}
+	chain = append(chain, client2.AuthRefreshMiddleware(ts, counters))
+	clientOpts = append(clientOpts, client2.WithTransportChain(chain...))
+
+	c, err := client2.NewClient(clientOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -134,11 +340,16 @@ func (b *LinnworksAPIBuilder) Build() (*LinnworksAPI, error) {
 		Orders:          ordersAPI,
 		ProcessedOrders: processedOrdersAPI,
 		Inventory:       inventoryAPI,
+		tokenSource:     ts,
+		authCounters:    counters,
 	}, nil
 }
 
 func (b *LinnworksAPIBuilder) requiredFields() {
-	if b.baseURL == "" {
+	if b.discoverURL && b.baseURL != "" {
+		b.err = append(b.err, errors.New("baseURL and BaseURLFromSession are mutually exclusive"))
+	}
+	if b.baseURL == "" && !b.discoverURL {
 		b.err = append(b.err, errors.New("baseURL is required"))
 	}
 	if b.token == "" {
@@ -77,6 +77,32 @@ func TestLinnworksAPIBuilder_Build(t *testing.T) {
 		}
 	})
 
+	t.Run("should not require baseURL when it comes from the session", func(t *testing.T) {
+		builder := NewLinnworksAPIBuilder().
+			BaseURLFromSession().
+			AuthURL("http://127.0.0.1:8080").
+			Token("test-token").
+			AppID("test-app-id").
+			AppSecret("test-app-secret")
+
+		if _, err := builder.Build(); err != nil {
+			t.Errorf("Expected nil error, got %v", err)
+		}
+	})
+
+	t.Run("should return an error if baseURL is combined with session discovery", func(t *testing.T) {
+		builder := NewLinnworksAPIBuilder().
+			BaseURL("https://eu-ext.linnworks.net").
+			BaseURLFromSession().
+			Token("test-token").
+			AppID("test-app-id").
+			AppSecret("test-app-secret")
+
+		if _, err := builder.Build(); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+
 	t.Run("all api branches have to be set", func(t *testing.T) {
 		builder := NewLinnworksAPIBuilder().
 			BaseURL("https://eu-ext.linnworks.net").
@@ -0,0 +1,501 @@
+// Package lwfake is an in-memory Linnworks API server for end-to-end tests of the SDK.
+//
+//	srv := lwfake.NewServer()
+//	defer srv.Close()
+//	srv.AddOrders(&models.OrderDetails{OrderID: id, NumOrderID: 1001})
+//	api, err := srv.APIBuilder().Build()
+//
+// The server issues session tokens, keeps orders, processed orders and stock locations in
+// memory and can inject faults: expired tokens, 429 with Retry-After, 5xx and slow responses.
+package lwfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+
+	lw_api "github.com/MMC-BK/lw-api"
+	authmodels "github.com/MMC-BK/lw-api/auth/models"
+	inventorymodels "github.com/MMC-BK/lw-api/inventory/models"
+	ordermodels "github.com/MMC-BK/lw-api/orders/models"
+	processedmodels "github.com/MMC-BK/lw-api/processedorders/models"
+)
+
+const (
+	AppID        = "lwfake-app"
+	AppSecret    = "lwfake-secret"
+	InstallToken = "lwfake-install-token"
+
+	authPath = "/api/Auth/AuthorizeByApplication"
+)
+
+// Fault is returned instead of the normal response for the next Times matching requests.
+type Fault struct {
+	// Status is the HTTP status to return; 0 keeps the normal response (useful with Delay).
+	Status int
+	// RetryAfter sets the Retry-After header in whole seconds.
+	RetryAfter time.Duration
+	// Delay is applied before responding.
+	Delay time.Duration
+	// Times is how many requests are affected; values below 1 mean one.
+	Times int
+}
+
+func RateLimited(retryAfter time.Duration) Fault {
+	return Fault{Status: http.StatusTooManyRequests, RetryAfter: retryAfter}
+}
+
+func ServerError() Fault { return Fault{Status: http.StatusInternalServerError} }
+
+func Slow(d time.Duration) Fault { return Fault{Delay: d} }
+
+type handlerFunc func(s *Server, w http.ResponseWriter, r *http.Request)
+
+type route struct {
+	method  string
+	handler handlerFunc
+}
+
+type Server struct {
+	srv *httptest.Server
+
+	mu             sync.Mutex
+	routes         map[string]route
+	tokenTTL       time.Duration
+	issued         int
+	tokens         map[string]time.Time
+	faults         map[string][]*Fault
+	calls          map[string]int
+	orders         map[strfmt.UUID]*ordermodels.OrderDetails
+	orderSeq       []strfmt.UUID
+	nextNumOrderID int32
+	nextRowID      int
+	processed      []*processedmodels.ProcessedOrderWeb
+	stockLocations []inventorymodels.StockLocation
+	countries      []ordermodels.OrderCountry
+	postalServices []ordermodels.PostageService
+}
+
+func NewServer() *Server {
+	s := &Server{
+		routes:         make(map[string]route),
+		tokenTTL:       30 * time.Minute,
+		tokens:         make(map[string]time.Time),
+		faults:         make(map[string][]*Fault),
+		calls:          make(map[string]int),
+		orders:         make(map[strfmt.UUID]*ordermodels.OrderDetails),
+		nextNumOrderID: 1000,
+	}
+	s.registerDefaultRoutes()
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *Server) URL() string { return s.srv.URL }
+
+func (s *Server) Close() { s.srv.Close() }
+
+// APIBuilder returns a builder wired to this server with valid credentials.
+func (s *Server) APIBuilder() *lw_api.LinnworksAPIBuilder {
+	return lw_api.NewLinnworksAPIBuilder().
+		BaseURL(s.URL()).
+		AuthURL(s.URL()).
+		AppID(AppID).
+		AppSecret(AppSecret).
+		Token(InstallToken)
+}
+
+// Handle registers or replaces an endpoint handler. Handlers run without the server lock.
+func (s *Server) Handle(method, path string, h func(s *Server, w http.ResponseWriter, r *http.Request)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes[path] = route{method: method, handler: h}
+}
+
+// SetTokenTTL changes the TTL reported for new sessions.
+func (s *Server) SetTokenTTL(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokenTTL = ttl
+}
+
+// ExpireTokens invalidates every issued session token so the next call gets 401.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = make(map[string]time.Time)
+}
+
+// InjectFault queues a fault for path, e.g. "/api/Orders/GetOrdersById".
+func (s *Server) InjectFault(path string, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f.Times < 1 {
+		f.Times = 1
+	}
+	s.faults[path] = append(s.faults[path], &f)
+}
+
+// Calls reports how many requests reached path, including faulted ones.
+func (s *Server) Calls(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[path]
+}
+
+// AddOrders seeds open or processed orders. Missing OrderID and NumOrderID are assigned.
+func (s *Server) AddOrders(orders ...*ordermodels.OrderDetails) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, o := range orders {
+		s.putOrderLocked(o)
+	}
+}
+
+// Order returns a copy of the stored order.
+func (s *Server) Order(id strfmt.UUID) (*ordermodels.OrderDetails, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orders[id]
+	if !ok {
+		return nil, false
+	}
+	return cloneOrder(o), true
+}
+
+func (s *Server) AddProcessedOrders(orders ...*processedmodels.ProcessedOrderWeb) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.processed = append(s.processed, orders...)
+}
+
+func (s *Server) AddStockLocations(locations ...inventorymodels.StockLocation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stockLocations = append(s.stockLocations, locations...)
+}
+
+// AddCountries seeds the list served by GetCountries.
+func (s *Server) AddCountries(countries ...ordermodels.OrderCountry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.countries = append(s.countries, countries...)
+}
+
+// AddPostalServices seeds the services ChangeShippingMethod and SetOrderShippingInfo accept.
+func (s *Server) AddPostalServices(services ...ordermodels.PostageService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.postalServices = append(s.postalServices, services...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.calls[r.URL.Path]++
+	fault := s.takeFaultLocked(r.URL.Path)
+	rt, ok := s.routes[r.URL.Path]
+	authorized := r.URL.Path == authPath || s.validTokenLocked(r.Header.Get("Authorization"))
+	s.mu.Unlock()
+
+	if fault != nil {
+		if fault.Delay > 0 {
+			select {
+			case <-time.After(fault.Delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if fault.Status != 0 {
+			if fault.RetryAfter > 0 {
+				w.Header().Set("Retry-After", fmt.Sprint(int(fault.RetryAfter.Seconds())))
+			}
+			writeError(w, fault.Status, http.StatusText(fault.Status))
+			return
+		}
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "endpoint is not implemented by lwfake: "+r.URL.Path)
+		return
+	}
+	if rt.method != r.Method {
+		writeError(w, http.StatusMethodNotAllowed, "expected "+rt.method)
+		return
+	}
+	if !authorized {
+		writeError(w, http.StatusUnauthorized, "Authorization has been denied for this request.")
+		return
+	}
+	rt.handler(s, w, r)
+}
+
+func (s *Server) takeFaultLocked(path string) *Fault {
+	queue := s.faults[path]
+	if len(queue) == 0 {
+		return nil
+	}
+	f := queue[0]
+	f.Times--
+	if f.Times <= 0 {
+		s.faults[path] = queue[1:]
+	}
+	return f
+}
+
+func (s *Server) validTokenLocked(token string) bool {
+	exp, ok := s.tokens[token]
+	return ok && time.Now().Before(exp)
+}
+
+func (s *Server) putOrderLocked(o *ordermodels.OrderDetails) {
+	if o.OrderID == "" {
+		o.OrderID = newUUID(len(s.orderSeq) + 1)
+	}
+	if o.NumOrderID == 0 {
+		s.nextNumOrderID++
+		o.NumOrderID = s.nextNumOrderID
+	} else if o.NumOrderID > s.nextNumOrderID {
+		s.nextNumOrderID = o.NumOrderID
+	}
+	for _, item := range o.Items {
+		if item != nil && item.RowID == "" {
+			item.RowID = s.newRowIDLocked()
+		}
+	}
+	if _, exists := s.orders[o.OrderID]; !exists {
+		s.orderSeq = append(s.orderSeq, o.OrderID)
+	}
+	s.orders[o.OrderID] = cloneOrder(o)
+}
+
+func (s *Server) registerDefaultRoutes() {
+	s.routes[authPath] = route{http.MethodPost, handleAuthorize}
+	s.routes["/api/Inventory/GetStockLocations"] = route{http.MethodGet, handleGetStockLocations}
+	s.routes["/api/Orders/GetOrdersById"] = route{http.MethodPost, handleGetOrdersByID}
+	s.routes["/api/Orders/GetOrderDetailsByNumOrderId"] = route{http.MethodGet, handleGetOrderDetailsByNumOrderID}
+	s.routes["/api/Orders/GetOpenOrders"] = route{http.MethodPost, handleGetOpenOrders}
+	s.routes["/api/Orders/CreateOrders"] = route{http.MethodPost, handleCreateOrders}
+	s.routes["/api/Orders/CreateNewOrder"] = route{http.MethodPost, handleCreateNewOrder}
+	s.routes["/api/Orders/ProcessOrder"] = route{http.MethodPost, handleProcessOrder}
+	s.routes["/api/Orders/ProcessOrdersInBatch"] = route{http.MethodPost, handleProcessOrdersInBatch}
+	s.routes["/api/Orders/ProcessOrderByOrderOrReferenceId"] = route{http.MethodPost, handleProcessOrderByOrderOrReferenceID}
+	s.routes["/api/Orders/ProcessOrder_RequiredBatchScans"] = route{http.MethodPost, handleProcessOrderRequiredBatchScans}
+	s.routes["/api/Orders/ProcessFulfilmentCentreOrder"] = route{http.MethodPost, handleProcessFulfilmentCentreOrder}
+	s.routes["/api/Orders/AddOrderItem"] = route{http.MethodPost, handleAddOrderItem}
+	s.routes["/api/Orders/RemoveOrderItem"] = route{http.MethodPost, handleRemoveOrderItem}
+	s.routes["/api/Orders/UpdateOrderItem"] = route{http.MethodPost, handleUpdateOrderItem}
+	s.routes["/api/Orders/UpdateLinkItem"] = route{http.MethodPost, handleUpdateLinkItem}
+	s.routes["/api/Orders/CreateNewItemAndLink"] = route{http.MethodPost, handleCreateNewItemAndLink}
+	s.routes["/api/Orders/GetCountries"] = route{http.MethodGet, handleGetCountries}
+	s.routes["/api/Orders/SetOrderCustomerInfo"] = route{http.MethodPost, handleSetOrderCustomerInfo}
+	s.routes["/api/Orders/SetOrderGeneralInfo"] = route{http.MethodPost, handleSetOrderGeneralInfo}
+	s.routes["/api/Orders/SetOrderShippingInfo"] = route{http.MethodPost, handleSetOrderShippingInfo}
+	s.routes["/api/Orders/SetOrderTotalsInfo"] = route{http.MethodPost, handleSetOrderTotalsInfo}
+	s.routes["/api/Orders/UpdateBillingAddress"] = route{http.MethodPost, handleUpdateBillingAddress}
+	s.routes["/api/Orders/ChangeShippingMethod"] = route{http.MethodPost, handleChangeShippingMethod}
+	s.routes["/api/Orders/LockOrder"] = route{http.MethodPost, handleLockOrder}
+	s.routes["/api/Orders/CancelOrder"] = route{http.MethodPost, handleCancelOrder}
+	s.routes["/api/Orders/DeleteOrder"] = route{http.MethodPost, handleDeleteOrder}
+	s.routes["/api/Orders/ChangeStatus"] = route{http.MethodPost, handleChangeStatus}
+	s.routes["/api/Orders/CompleteOrder"] = route{http.MethodPost, handleCompleteOrder}
+	s.routes["/api/ProcessedOrders/SearchProcessedOrders"] = route{http.MethodPost, handleSearchProcessedOrders}
+}
+
+func handleAuthorize(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in struct {
+		ApplicationID     string `json:"applicationId"`
+		ApplicationSecret string `json:"applicationSecret"`
+		Token             string `json:"token"`
+	}
+	if !decode(w, r, &in) {
+		return
+	}
+	if in.ApplicationID != AppID || in.ApplicationSecret != AppSecret || in.Token == "" {
+		writeError(w, http.StatusUnauthorized, "invalid application credentials")
+		return
+	}
+	s.mu.Lock()
+	s.issued++
+	token := string(newUUID(s.issued))
+	ttl := s.tokenTTL
+	s.tokens[token] = time.Now().Add(ttl)
+	s.mu.Unlock()
+
+	writeJSON(w, authmodels.BaseSession{
+		Token:  strfmt.UUID(token),
+		TTL:    int32(ttl / time.Second),
+		Server: s.URL(),
+	})
+}
+
+func handleGetStockLocations(s *Server, w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	out := append([]inventorymodels.StockLocation{}, s.stockLocations...)
+	s.mu.Unlock()
+	writeJSON(w, out)
+}
+
+func handleGetOrdersByID(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersGetOrdersByIDRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	s.mu.Lock()
+	out := make([]*ordermodels.OrderDetails, 0, len(in.PkOrderIds))
+	for _, id := range in.PkOrderIds {
+		if o, ok := s.orders[id]; ok {
+			out = append(out, cloneOrder(o))
+		}
+	}
+	s.mu.Unlock()
+	writeJSON(w, out)
+}
+
+func handleGetOrderDetailsByNumOrderID(s *Server, w http.ResponseWriter, r *http.Request) {
+	var num int32
+	if _, err := fmt.Sscan(r.URL.Query().Get("OrderId"), &num); err != nil {
+		writeError(w, http.StatusBadRequest, "OrderId must be an integer")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range s.orderSeq {
+		if o := s.orders[id]; o.NumOrderID == num {
+			writeJSON(w, o)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("order %d not found", num))
+}
+
+func handleGetOpenOrders(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersGetOpenOrdersRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	s.mu.Lock()
+	var open []*ordermodels.OpenOrder
+	for _, id := range s.orderSeq {
+		o := s.orders[id]
+		if o.Processed {
+			continue
+		}
+		open = append(open, toOpenOrder(o))
+	}
+	s.mu.Unlock()
+
+	data, page, perPage, total, pages := paginate(open, in.PageNumber, in.EntriesPerPage)
+	writeJSON(w, ordermodels.GenericPagedResultOpenOrder{
+		Data: data, PageNumber: page, EntriesPerPage: perPage, TotalEntries: total, TotalPages: pages,
+	})
+}
+
+func handleSearchProcessedOrders(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in processedmodels.ProcessedOrdersSearchProcessedOrdersRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	req := in.Request
+	if req == nil {
+		req = &processedmodels.SearchProcessedOrdersRequest{}
+	}
+	term := strings.ToLower(req.SearchTerm)
+
+	s.mu.Lock()
+	all := append([]*processedmodels.ProcessedOrderWeb{}, s.processed...)
+	for _, id := range s.orderSeq {
+		if o := s.orders[id]; o.Processed {
+			all = append(all, toProcessedOrderWeb(o))
+		}
+	}
+	s.mu.Unlock()
+
+	var matched []*processedmodels.ProcessedOrderWeb
+	for _, o := range all {
+		if term == "" ||
+			strings.Contains(strings.ToLower(o.ReferenceNum), term) ||
+			strings.Contains(strings.ToLower(o.SecondaryReference), term) ||
+			fmt.Sprint(o.NOrderID) == term {
+			matched = append(matched, o)
+		}
+	}
+	data, page, perPage, total, pages := paginate(matched, req.PageNumber, req.ResultsPerPage)
+	writeJSON(w, processedmodels.SearchProcessedOrdersResponse{
+		ProcessedOrders: &processedmodels.GenericPagedResultProcessedOrderWeb{
+			Data: data, PageNumber: page, EntriesPerPage: perPage, TotalEntries: total, TotalPages: pages,
+		},
+	})
+}
+
+func toProcessedOrderWeb(o *ordermodels.OrderDetails) *processedmodels.ProcessedOrderWeb {
+	out := &processedmodels.ProcessedOrderWeb{
+		PkOrderID:    o.OrderID,
+		NOrderID:     o.NumOrderID,
+		DProcessedOn: o.ProcessedDateTime,
+	}
+	if gi := o.GeneralInfo; gi != nil {
+		out.ReferenceNum = gi.ReferenceNum
+		out.SecondaryReference = gi.SecondaryReference
+		out.Source = gi.Source
+		out.SubSource = gi.SubSource
+		out.HoldOrCancel = gi.HoldOrCancel
+	}
+	return out
+}
+
+func paginate[T any](items []T, page, perPage int32) ([]T, int32, int32, int64, int32) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 100
+	}
+	total := int64(len(items))
+	pages := int32((total + int64(perPage) - 1) / int64(perPage))
+	start := int64(page-1) * int64(perPage)
+	if start >= total {
+		return []T{}, page, perPage, total, pages
+	}
+	end := min(start+int64(perPage), total)
+	return items[start:end], page, perPage, total, pages
+}
+
+func cloneOrder(o *ordermodels.OrderDetails) *ordermodels.OrderDetails {
+	data, _ := json.Marshal(o)
+	var out ordermodels.OrderDetails
+	_ = json.Unmarshal(data, &out)
+	return &out
+}
+
+func newUUID(n int) strfmt.UUID {
+	return strfmt.UUID(fmt.Sprintf("1f0e0000-0000-4000-8000-%012d", n))
+}
+
+func (s *Server) newRowIDLocked() strfmt.UUID {
+	s.nextRowID++
+	return strfmt.UUID(fmt.Sprintf("2f0e0000-0000-4000-8000-%012d", s.nextRowID))
+}
+
+func decode(w http.ResponseWriter, r *http.Request, out any) bool {
+	if err := json.NewDecoder(r.Body).Decode(out); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"Code": http.StatusText(status), "Message": message})
+}
@@ -0,0 +1,352 @@
+package lwfake_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+
+	lw_api "github.com/MMC-BK/lw-api"
+	"github.com/MMC-BK/lw-api/client"
+	inventorymodels "github.com/MMC-BK/lw-api/inventory/models"
+	"github.com/MMC-BK/lw-api/lwfake"
+	"github.com/MMC-BK/lw-api/orders"
+	ordermodels "github.com/MMC-BK/lw-api/orders/models"
+)
+
+func seeded(t *testing.T) (*lwfake.Server, *lw_api.LinnworksAPI) {
+	t.Helper()
+	srv := lwfake.NewServer()
+	t.Cleanup(srv.Close)
+	srv.AddStockLocations(inventorymodels.StockLocation{LocationName: "Default"})
+	srv.AddOrders(
+		&ordermodels.OrderDetails{NumOrderID: 1001, GeneralInfo: &ordermodels.OrderGeneralInfo{ReferenceNum: "A-1"}},
+		&ordermodels.OrderDetails{NumOrderID: 1002, GeneralInfo: &ordermodels.OrderGeneralInfo{ReferenceNum: "A-2"}},
+		&ordermodels.OrderDetails{NumOrderID: 1003, Processed: true, GeneralInfo: &ordermodels.OrderGeneralInfo{ReferenceNum: "B-3"}},
+	)
+	api, err := srv.APIBuilder().
+		RetryPolicy(client.RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond, MaxRetryAfter: 2 * time.Second}).
+		Build()
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	t.Cleanup(func() { api.Close() })
+	return srv, api
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should serve seeded data through the builders", func(t *testing.T) {
+		_, api := seeded(t)
+
+		locations, err := api.GetStockLocations(ctx).Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if len(locations) != 1 || locations[0].LocationName != "Default" {
+			t.Errorf("unexpected locations %+v", locations)
+		}
+
+		order, err := api.GetOrderDetailsByNumOrderId(ctx).OrderID(1002).Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if order.GeneralInfo.ReferenceNum != "A-2" {
+			t.Errorf("unexpected order %+v", order.GeneralInfo)
+		}
+
+		byID, err := api.GetOrdersById(ctx).PkOrderIds([]strfmt.UUID{order.OrderID}).Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if len(byID) != 1 || byID[0].NumOrderID != 1002 {
+			t.Errorf("unexpected orders %+v", byID)
+		}
+
+		open, err := api.GetOpenOrders(ctx).EntriesPerPage(1).PageNumber(1).Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if open.TotalEntries != 2 || open.TotalPages != 2 || len(open.Data) != 1 {
+			t.Errorf("unexpected open orders page %+v", open)
+		}
+
+		processed, err := api.SearchProcessedOrders(ctx).SearchTerm("B-3").PageNumber(1).ResultsPerPage(10).Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if processed.TotalEntries != 1 || processed.Data[0].NOrderID != 1003 {
+			t.Errorf("unexpected processed orders %+v", processed)
+		}
+	})
+
+	t.Run("should create channel orders and update them by reference", func(t *testing.T) {
+		srv, api := seeded(t)
+		order := func(qty int32) *orders.ChannelOrderBuilder {
+			return orders.NewChannelOrderBuilder().
+				ReferenceNumber("WEB-1").
+				Source("MYSHOP").
+				SubSource("myshop.example").
+				Items(orders.NewChannelOrderItemBuilder().ItemNumber("1").ChannelSKU("SKU-1").Qty(qty).PricePerUnit(10))
+		}
+		ids, err := api.CreateOrders(ctx).Orders(order(1)).Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		again, err := api.CreateOrders(ctx).Orders(order(3)).Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if len(ids) != 1 || len(again) != 1 || ids[0] != again[0] {
+			t.Fatalf("Expected the same order id, got %v and %v", ids, again)
+		}
+		stored, ok := srv.Order(ids[0])
+		if !ok || stored.Items[0].Quantity != 3 || stored.TotalsInfo.Subtotal != 30 {
+			t.Errorf("unexpected stored order %+v", stored)
+		}
+	})
+
+	t.Run("should process open orders and refuse the rest", func(t *testing.T) {
+		srv, api := seeded(t)
+		open, err := api.GetOpenOrders(ctx).EntriesPerPage(10).PageNumber(1).Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		processed, err := api.SearchProcessedOrders(ctx).SearchTerm("B-3").PageNumber(1).ResultsPerPage(10).Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		ids := []strfmt.UUID{open.Data[0].OrderID, processed.Data[0].PkOrderID}
+
+		report, err := api.ProcessMany(ctx).OrderIds(ids).BatchSize(1).Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if len(report.Succeeded) != 1 || len(report.Failed) != 1 || report.Failed[0].Error != "order is already processed" {
+			t.Errorf("unexpected report %+v", report)
+		}
+		if stored, _ := srv.Order(ids[0]); !stored.Processed {
+			t.Error("Expected order to be processed")
+		}
+
+		res, err := api.ProcessOrderByOrderOrReferenceId(ctx).OrderOrReferenceID("A-2").Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if res.ProcessedState != ordermodels.ProcessOrderByOrderIDOrReferenceResponseProcessedStatePROCESSED {
+			t.Errorf("unexpected response %+v", res)
+		}
+	})
+
+	t.Run("should edit order lines and recalculate totals", func(t *testing.T) {
+		srv, _ := seeded(t)
+		srv.AddOrders(&ordermodels.OrderDetails{
+			NumOrderID:           2001,
+			FulfilmentLocationID: "00000000-0000-0000-0000-000000000000",
+			GeneralInfo:          &ordermodels.OrderGeneralInfo{Source: "MYSHOP", SubSource: "myshop.example"},
+			Items: []*ordermodels.OrderItem{
+				{ChannelSKU: "SKU-1", Quantity: 1, PricePerUnit: 10},
+				{ChannelSKU: "SKU-2", Quantity: 1, PricePerUnit: 5},
+			},
+		})
+		api, err := srv.APIBuilder().Build()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		defer api.Close()
+
+		ed, err := api.EditOrder(ctx, 2001)
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		items, err := ed.Items()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		res, err := ed.
+			SetQuantity(items[0].RowID, 3).
+			Remove(items[1].RowID).
+			Add(orders.NewOrderItem{ItemID: "00000000-0000-4000-8000-000000000200", ChannelSKU: "SKU-3", Quantity: 2, Pricing: &ordermodels.LinePricingRequest{PricePerUnit: 1}}).
+			Save()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if res.Totals == nil || res.Totals.Subtotal != 32 {
+			t.Errorf("unexpected totals %+v", res.Totals)
+		}
+		if len(res.Added) != 1 || res.Added[0].Item.RowID == "" {
+			t.Errorf("Expected the added line to get a row id, got %+v", res.Added)
+		}
+
+		if err := api.UpdateLinkItem(ctx).ChannelSKU("SKU-1").Source("MYSHOP", "myshop.example").PkStockItemID("00000000-0000-4000-8000-000000000201").Do(); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		stored, _ := srv.Order(ed.Order().OrderID)
+		if len(stored.Items) != 2 || stored.Items[0].StockItemID != "00000000-0000-4000-8000-000000000201" {
+			t.Errorf("unexpected stored items %+v", stored.Items)
+		}
+	})
+
+	t.Run("should patch order headers", func(t *testing.T) {
+		srv, _ := seeded(t)
+		srv.AddCountries(ordermodels.OrderCountry{CountryName: "United Kingdom", CountryCode: "GB", CountryID: "00000000-0000-4000-8000-000000000001"})
+		standard := ordermodels.PostageService{PostalServiceName: "Standard", PkPostalServiceID: "00000000-0000-4000-8000-000000000301"}
+		express := ordermodels.PostageService{PostalServiceName: "Express", PkPostalServiceID: "00000000-0000-4000-8000-000000000302"}
+		srv.AddPostalServices(standard, express)
+		srv.AddOrders(&ordermodels.OrderDetails{
+			NumOrderID:   2001,
+			GeneralInfo:  &ordermodels.OrderGeneralInfo{ReferenceNum: "C-1"},
+			CustomerInfo: &ordermodels.OrderCustomerInfo{Address: &ordermodels.CustomerAddress{FullName: "J Doe", Address1: "1 High St"}},
+			ShippingInfo: &ordermodels.OrderShippingInfo{PostalServiceName: standard.PostalServiceName, PostalServiceID: standard.PkPostalServiceID},
+			Items:        []*ordermodels.OrderItem{{ChannelSKU: "SKU-1", Quantity: 2, PricePerUnit: 5}},
+		})
+		api, err := srv.APIBuilder().Build()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		defer api.Close()
+
+		order, err := api.GetOrderDetailsByNumOrderId(ctx).OrderID(2001).Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		res, err := api.PatchOrder(ctx, order.OrderID).
+			DeliveryAddress(orders.NewAddressPatch().CountryCode("GB")).
+			SecondaryReference("PO-7").
+			ShippingMethod("Express").
+			PostageCost(4).
+			Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if res.Shipping == nil || res.Shipping.TotalsInfo.TotalCharge != 14 {
+			t.Errorf("unexpected shipping result %+v", res.Shipping)
+		}
+		stored, _ := srv.Order(order.OrderID)
+		if stored.CustomerInfo.Address.Country != "United Kingdom" || stored.CustomerInfo.Address.Address1 != "1 High St" {
+			t.Errorf("unexpected address %+v", stored.CustomerInfo.Address)
+		}
+		if stored.GeneralInfo.ReferenceNum != "C-1" || stored.GeneralInfo.SecondaryReference != "PO-7" {
+			t.Errorf("unexpected general info %+v", stored.GeneralInfo)
+		}
+		if si := stored.ShippingInfo; si.PostalServiceName != "Express" || si.PostalServiceID != express.PkPostalServiceID || si.PostageCost != 4 {
+			t.Errorf("unexpected shipping info %+v", stored.ShippingInfo)
+		}
+	})
+
+	t.Run("should move orders through their lifecycle", func(t *testing.T) {
+		srv, api := seeded(t)
+		open, err := api.GetOpenOrders(ctx).EntriesPerPage(10).PageNumber(1).Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		ids := []strfmt.UUID{open.Data[0].OrderID, open.Data[1].OrderID}
+
+		report, err := api.TransitionOrders(ctx).OrderIds(ids[:1]).Lock().Do()
+		if err != nil || report.Err() != nil {
+			t.Fatalf("Expected nil error, got %v, %v", err, report.Err())
+		}
+		report, err = api.TransitionOrders(ctx).OrderIds(ids).Delete().Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		var te *orders.TransitionError
+		if !errors.As(report.Err(), &te) || te.OrderID != ids[0] || te.State != orders.OrderStateLocked {
+			t.Errorf("Expected the locked order to be rejected, got %v", report.Err())
+		}
+		if _, ok := srv.Order(ids[1]); ok {
+			t.Error("Expected the unlocked order to be deleted")
+		}
+
+		report, err = api.TransitionOrders(ctx).OrderIds(ids[:1]).Cancel().FulfilmentCenter("00000000-0000-0000-0000-000000000000").Do()
+		if err != nil || report.Err() != nil {
+			t.Fatalf("Expected nil error, got %v, %v", err, report.Err())
+		}
+		stored, _ := srv.Order(ids[0])
+		if orders.StateOf(stored) != orders.OrderStateCancelled {
+			t.Errorf("Expected cancelled order, got %s", orders.StateOf(stored))
+		}
+		report, err = api.TransitionOrders(ctx).OrderIds(ids[:1]).Complete().Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if !errors.Is(report.Err(), orders.ErrTransitionNotAllowed) {
+			t.Errorf("Expected transition error, got %v", report.Err())
+		}
+	})
+
+	t.Run("should return 404 for unknown order", func(t *testing.T) {
+		_, api := seeded(t)
+		_, err := api.GetOrderDetailsByNumOrderId(ctx).OrderID(42).Do()
+		if !client.IsNotFound(err) {
+			t.Errorf("Expected not found error, got %v", err)
+		}
+	})
+
+	t.Run("should refresh expired session and re-sign the call", func(t *testing.T) {
+		srv, api := seeded(t)
+		if _, err := api.GetStockLocations(ctx).Do(); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		srv.ExpireTokens()
+		if _, err := api.GetStockLocations(ctx).Do(); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if got := api.AuthCounters().Refreshes(); got != 1 {
+			t.Errorf("Expected 1 refresh, got %d", got)
+		}
+		if got := srv.Calls("/api/Auth/AuthorizeByApplication"); got != 2 {
+			t.Errorf("Expected 2 authorize calls, got %d", got)
+		}
+	})
+
+	t.Run("should retry after 429 and 500", func(t *testing.T) {
+		srv, api := seeded(t)
+		srv.InjectFault("/api/Inventory/GetStockLocations", lwfake.RateLimited(time.Second))
+		srv.InjectFault("/api/Inventory/GetStockLocations", lwfake.ServerError())
+		if _, err := api.GetStockLocations(ctx).Do(); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if got := srv.Calls("/api/Inventory/GetStockLocations"); got != 3 {
+			t.Errorf("Expected 3 calls, got %d", got)
+		}
+	})
+
+	t.Run("should apply per-call options", func(t *testing.T) {
+		srv, api := seeded(t)
+		srv.InjectFault("/api/Inventory/GetStockLocations", lwfake.ServerError())
+		_, err := api.GetStockLocations(ctx).WithOptions(lw_api.NoRetry()).Do()
+		if !client.IsServerError(err) {
+			t.Errorf("Expected server error, got %v", err)
+		}
+		if got := srv.Calls("/api/Inventory/GetStockLocations"); got != 1 {
+			t.Errorf("Expected 1 call, got %d", got)
+		}
+		srv.InjectFault("/api/Inventory/GetStockLocations", lwfake.Slow(time.Second))
+		if _, err := api.GetStockLocations(ctx).WithOptions(lw_api.Timeout(50 * time.Millisecond)).Do(); err == nil {
+			t.Error("Expected timeout error, got nil")
+		}
+	})
+
+	t.Run("should surface persistent server errors", func(t *testing.T) {
+		srv, api := seeded(t)
+		srv.InjectFault("/api/Inventory/GetStockLocations", lwfake.Fault{Status: 500, Times: 5})
+		_, err := api.GetStockLocations(ctx).Do()
+		if !client.IsServerError(err) {
+			t.Errorf("Expected server error, got %v", err)
+		}
+	})
+
+	t.Run("should honour context deadline on slow responses", func(t *testing.T) {
+		srv, api := seeded(t)
+		srv.InjectFault("/api/Inventory/GetStockLocations", lwfake.Slow(time.Second))
+		tctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		if _, err := api.GetStockLocations(tctx).Do(); err == nil {
+			t.Error("Expected timeout error, got nil")
+		}
+	})
+}
@@ -0,0 +1,730 @@
+package lwfake
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+
+	ordermodels "github.com/MMC-BK/lw-api/orders/models"
+)
+
+// handleCreateOrders stores channel orders as open orders. An order with the Source, SubSource
+// and ReferenceNumber of a stored one replaces it, as Linnworks does for re-downloaded orders.
+func handleCreateOrders(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersCreateOrdersRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	if len(in.Orders) == 0 {
+		writeError(w, http.StatusBadRequest, "orders must contain at least one order")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]strfmt.UUID, 0, len(in.Orders))
+	for _, co := range in.Orders {
+		o := fromChannelOrder(co)
+		for _, id := range s.orderSeq {
+			if gi := s.orders[id].GeneralInfo; gi != nil && gi.Source == co.Source && gi.SubSource == co.SubSource && gi.ReferenceNum == co.ReferenceNumber {
+				o.OrderID, o.NumOrderID = id, s.orders[id].NumOrderID
+			}
+		}
+		s.putOrderLocked(o)
+		ids = append(ids, o.OrderID)
+	}
+	writeJSON(w, ids)
+}
+
+func handleCreateNewOrder(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersCreateNewOrderRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	if in.FulfilmentCenter == "" {
+		writeError(w, http.StatusBadRequest, "fulfilmentCenter is required")
+		return
+	}
+	o := &ordermodels.OrderDetails{
+		FulfilmentLocationID: in.FulfilmentCenter,
+		GeneralInfo:          &ordermodels.OrderGeneralInfo{Location: in.FulfilmentCenter, Source: "DIRECT", SubSource: "DIRECT"},
+	}
+	s.mu.Lock()
+	s.putOrderLocked(o)
+	s.mu.Unlock()
+	writeJSON(w, toOpenOrder(o))
+}
+
+func handleProcessOrder(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersProcessOrderRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, s.processOrderLocked(in.OrderID))
+}
+
+func handleProcessOrdersInBatch(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersProcessOrdersInBatchRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	if len(in.OrdersIds) == 0 {
+		writeError(w, http.StatusBadRequest, "ordersIds must contain at least one value")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]*ordermodels.ProcessOrderResult, 0, len(in.OrdersIds))
+	for _, id := range in.OrdersIds {
+		out = append(out, s.processOrderLocked(id))
+	}
+	writeJSON(w, out)
+}
+
+// handleProcessOrderByOrderOrReferenceID looks the order up by number first, then by channel reference.
+func handleProcessOrderByOrderOrReferenceID(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersProcessOrderByOrderOrReferenceIDRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	if in.Request == nil || in.Request.OrderOrReferenceID == "" {
+		writeError(w, http.StatusBadRequest, "orderOrReferenceId is required")
+		return
+	}
+	key := in.Request.OrderOrReferenceID
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var found *ordermodels.OrderDetails
+	if num, err := strconv.ParseInt(key, 10, 32); err == nil {
+		for _, id := range s.orderSeq {
+			if o := s.orders[id]; o.NumOrderID == int32(num) {
+				found = o
+				break
+			}
+		}
+	}
+	for _, id := range s.orderSeq {
+		if found != nil {
+			break
+		}
+		if o := s.orders[id]; o.GeneralInfo != nil && o.GeneralInfo.ReferenceNum == key {
+			found = o
+		}
+	}
+	if found == nil {
+		writeJSON(w, ordermodels.ProcessOrderByOrderIDOrReferenceResponse{
+			ProcessedState: ordermodels.ProcessOrderByOrderIDOrReferenceResponseProcessedStateNOTFOUND,
+			Message:        "order " + key + " not found",
+		})
+		return
+	}
+	res := s.processOrderLocked(found.OrderID)
+	out := ordermodels.ProcessOrderByOrderIDOrReferenceResponse{
+		OrderID:        found.OrderID,
+		ProcessedState: ordermodels.ProcessOrderByOrderIDOrReferenceResponseProcessedStatePROCESSED,
+	}
+	if !res.Processed {
+		out.ProcessedState = ordermodels.ProcessOrderByOrderIDOrReferenceResponseProcessedStateNOTPROCESSED
+		out.Message = res.Error
+	}
+	writeJSON(w, out)
+}
+
+func handleProcessOrderRequiredBatchScans(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersProcessOrderRequiredBatchScansRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	if in.BatchAssignment == nil || len(in.BatchAssignment.BatchToItemMapping) == 0 {
+		writeError(w, http.StatusBadRequest, "batchToItemMapping must contain at least one value")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, s.processOrderLocked(in.BatchAssignment.OrderID))
+}
+
+func handleProcessFulfilmentCentreOrder(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersProcessFulfilmentCentreOrderRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, s.processOrderLocked(in.OrderID))
+}
+
+// processOrderLocked marks an open order processed. Like Linnworks it reports refusals in the
+// result instead of failing the request.
+func (s *Server) processOrderLocked(id strfmt.UUID) *ordermodels.ProcessOrderResult {
+	out := &ordermodels.ProcessOrderResult{OrderID: id}
+	o, ok := s.orders[id]
+	switch {
+	case !ok:
+		out.Error = "order not found"
+	case o.Processed:
+		out.Error = "order is already processed"
+	case o.GeneralInfo != nil && o.GeneralInfo.IsParked:
+		out.Error = "order is parked"
+	case o.GeneralInfo != nil && o.GeneralInfo.HoldOrCancel:
+		out.Error = "order is on hold"
+	default:
+		o.Processed = true
+		o.ProcessedDateTime = strfmt.DateTime(time.Now().UTC())
+		out.Processed = true
+	}
+	return out
+}
+
+func handleAddOrderItem(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersAddOrderItemRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	if in.Quantity <= 0 {
+		writeError(w, http.StatusBadRequest, "quantity must be greater than 0")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.openOrderLocked(w, in.OrderID)
+	if !ok {
+		return
+	}
+	item := &ordermodels.OrderItem{
+		RowID:       s.newRowIDLocked(),
+		OrderID:     o.OrderID,
+		ItemID:      in.ItemID,
+		StockItemID: in.ItemID,
+		ChannelSKU:  in.ChannelSKU,
+		SKU:         in.ChannelSKU,
+		Quantity:    in.Quantity,
+		AddedDate:   in.CreatedDate,
+	}
+	if p := in.LinePricing; p != nil {
+		item.PricePerUnit = p.PricePerUnit
+		item.Discount = p.DiscountPercentage
+		item.TaxRate = p.TaxRatePercentage
+		item.TaxCostInclusive = p.TaxInclusive
+	}
+	o.Items = append(o.Items, item)
+	recalcTotals(o)
+	writeJSON(w, ordermodels.UpdateOrderItemResult{Item: item, TotalsInfo: o.TotalsInfo})
+}
+
+func handleRemoveOrderItem(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersRemoveOrderItemRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.openOrderLocked(w, in.OrderID)
+	if !ok {
+		return
+	}
+	i := itemIndex(o, in.Rowid)
+	if i < 0 {
+		writeError(w, http.StatusBadRequest, "row "+in.Rowid.String()+" is not on the order")
+		return
+	}
+	o.Items = append(o.Items[:i], o.Items[i+1:]...)
+	recalcTotals(o)
+	writeJSON(w, ordermodels.UpdateTotalsResult{ShippingInfo: o.ShippingInfo, TotalsInfo: o.TotalsInfo})
+}
+
+func handleUpdateOrderItem(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersUpdateOrderItemRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	if in.OrderItem == nil {
+		writeError(w, http.StatusBadRequest, "orderItem is required")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.openOrderLocked(w, in.OrderID)
+	if !ok {
+		return
+	}
+	i := itemIndex(o, in.OrderItem.RowID)
+	if i < 0 {
+		writeError(w, http.StatusBadRequest, "row "+in.OrderItem.RowID.String()+" is not on the order")
+		return
+	}
+	in.OrderItem.OrderID = o.OrderID
+	o.Items[i] = in.OrderItem
+	recalcTotals(o)
+	writeJSON(w, ordermodels.UpdateTotalsResult{ShippingInfo: o.ShippingInfo, TotalsInfo: o.TotalsInfo})
+}
+
+func handleUpdateLinkItem(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersUpdateLinkItemRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	if in.ChannelSKU == "" || in.PkStockItemID == "" {
+		writeError(w, http.StatusBadRequest, "channelSKU and pkStockItemId are required")
+		return
+	}
+	s.mu.Lock()
+	s.linkItemsLocked(in.Source, in.SubSource, in.ChannelSKU, in.PkStockItemID)
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleCreateNewItemAndLink(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersCreateNewItemAndLinkRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	if in.ChannelSKU == "" || in.ItemTitle == "" {
+		writeError(w, http.StatusBadRequest, "channelSKU and itemTitle are required")
+		return
+	}
+	s.mu.Lock()
+	id := in.PkStockItemID
+	if id == "" {
+		id = s.newRowIDLocked()
+	}
+	s.linkItemsLocked(in.Source, in.SubSource, in.ChannelSKU, id)
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleGetCountries(s *Server, w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	out := append([]ordermodels.OrderCountry{}, s.countries...)
+	s.mu.Unlock()
+	writeJSON(w, out)
+}
+
+func handleSetOrderCustomerInfo(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersSetOrderCustomerInfoRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	if in.Info == nil {
+		writeError(w, http.StatusBadRequest, "info is required")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.openOrderLocked(w, in.OrderID)
+	if !ok {
+		return
+	}
+	o.CustomerInfo = in.Info
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleSetOrderGeneralInfo(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersSetOrderGeneralInfoRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	if in.Info == nil {
+		writeError(w, http.StatusBadRequest, "info is required")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.openOrderLocked(w, in.OrderID)
+	if !ok {
+		return
+	}
+	in.Info.NumItems = int32(len(o.Items))
+	o.GeneralInfo = in.Info
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleSetOrderShippingInfo keeps the package fields, which the request does not carry, takes
+// the service name from PostalServiceId and moves the postage cost into the totals.
+func handleSetOrderShippingInfo(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersSetOrderShippingInfoRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	if in.Info == nil {
+		writeError(w, http.StatusBadRequest, "info is required")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.openOrderLocked(w, in.OrderID)
+	if !ok {
+		return
+	}
+	if o.ShippingInfo == nil {
+		o.ShippingInfo = &ordermodels.OrderShippingInfo{}
+	}
+	si := o.ShippingInfo
+	si.ItemWeight, si.ManualAdjust, si.PostageCost = in.Info.ItemWeight, in.Info.ManualAdjust, in.Info.PostageCost
+	si.PostalServiceID, si.TotalWeight, si.TrackingNumber = in.Info.PostalServiceID, in.Info.TotalWeight, in.Info.TrackingNumber
+	if svc := s.postalServiceLocked(func(p *ordermodels.PostageService) bool { return p.PkPostalServiceID == si.PostalServiceID }); svc != nil {
+		si.PostalServiceName = svc.PostalServiceName
+	}
+	if o.TotalsInfo == nil {
+		o.TotalsInfo = &ordermodels.OrderTotalsInfo{}
+	}
+	o.TotalsInfo.PostageCost = si.PostageCost
+	recalcTotals(o)
+	writeJSON(w, ordermodels.UpdateTotalsResult{ShippingInfo: o.ShippingInfo, TotalsInfo: o.TotalsInfo})
+}
+
+func handleSetOrderTotalsInfo(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersSetOrderTotalsInfoRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	if in.Info == nil {
+		writeError(w, http.StatusBadRequest, "info is required")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.openOrderLocked(w, in.OrderID)
+	if !ok {
+		return
+	}
+	o.TotalsInfo = in.Info
+	recalcTotals(o)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleUpdateBillingAddress(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersUpdateBillingAddressRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	if in.BillingAddress == nil {
+		writeError(w, http.StatusBadRequest, "billingAddress is required")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.openOrderLocked(w, in.OrderID)
+	if !ok {
+		return
+	}
+	if o.CustomerInfo == nil {
+		o.CustomerInfo = &ordermodels.OrderCustomerInfo{}
+	}
+	o.CustomerInfo.BillingAddress = in.BillingAddress
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleChangeShippingMethod(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersChangeShippingMethodRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	if in.ShippingMethod == "" {
+		writeError(w, http.StatusBadRequest, "shippingMethod is required")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	targets, ok := s.openOrdersLocked(w, in.OrderIds)
+	if !ok {
+		return
+	}
+	svc := s.postalServiceLocked(func(p *ordermodels.PostageService) bool { return p.PostalServiceName == in.ShippingMethod })
+	if svc == nil {
+		writeError(w, http.StatusBadRequest, "unknown shipping method "+in.ShippingMethod)
+		return
+	}
+	for _, o := range targets {
+		if o.ShippingInfo == nil {
+			o.ShippingInfo = &ordermodels.OrderShippingInfo{}
+		}
+		o.ShippingInfo.PostalServiceName = svc.PostalServiceName
+		o.ShippingInfo.PostalServiceID = svc.PkPostalServiceID
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) postalServiceLocked(match func(*ordermodels.PostageService) bool) *ordermodels.PostageService {
+	for i := range s.postalServices {
+		if match(&s.postalServices[i]) {
+			return &s.postalServices[i]
+		}
+	}
+	return nil
+}
+
+// handleLockOrder puts open orders on hold or releases them.
+func handleLockOrder(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersLockOrderRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	targets, ok := s.openOrdersLocked(w, in.OrderIds)
+	if !ok {
+		return
+	}
+	for _, o := range targets {
+		if o.GeneralInfo == nil {
+			o.GeneralInfo = &ordermodels.OrderGeneralInfo{}
+		}
+		o.GeneralInfo.HoldOrCancel = in.LockOrder
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleChangeStatus(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersChangeStatusRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	targets, ok := s.openOrdersLocked(w, in.OrderIds)
+	if !ok {
+		return
+	}
+	for _, o := range targets {
+		if o.GeneralInfo == nil {
+			o.GeneralInfo = &ordermodels.OrderGeneralInfo{}
+		}
+		o.GeneralInfo.Status = in.Status
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleCancelOrder moves the order to processed with HoldOrCancel set, which is how Linnworks
+// reports a cancelled order.
+func handleCancelOrder(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersCancelOrderRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	if in.FulfilmentCenter == "" {
+		writeError(w, http.StatusBadRequest, "fulfilmentCenter is required")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.openOrderLocked(w, in.OrderID)
+	if !ok {
+		return
+	}
+	if o.GeneralInfo == nil {
+		o.GeneralInfo = &ordermodels.OrderGeneralInfo{}
+	}
+	o.GeneralInfo.HoldOrCancel = true
+	o.Processed = true
+	o.ProcessedDateTime = strfmt.DateTime(time.Now().UTC())
+	if in.Note != "" {
+		o.Notes = append(o.Notes, &ordermodels.OrderNote{Note: in.Note})
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleDeleteOrder(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersDeleteOrderRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.openOrderLocked(w, in.OrderID); !ok {
+		return
+	}
+	delete(s.orders, in.OrderID)
+	for i, id := range s.orderSeq {
+		if id == in.OrderID {
+			s.orderSeq = append(s.orderSeq[:i], s.orderSeq[i+1:]...)
+			break
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleCompleteOrder(s *Server, w http.ResponseWriter, r *http.Request) {
+	var in ordermodels.OrdersCompleteOrderRequest
+	if !decode(w, r, &in) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.openOrderLocked(w, in.OrderID); !ok {
+		return
+	}
+	if res := s.processOrderLocked(in.OrderID); !res.Processed {
+		writeError(w, http.StatusBadRequest, res.Error)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// openOrderLocked writes the error response itself when the order cannot be edited.
+func (s *Server) openOrderLocked(w http.ResponseWriter, id strfmt.UUID) (*ordermodels.OrderDetails, bool) {
+	o, ok := s.orders[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "order "+id.String()+" not found")
+		return nil, false
+	}
+	if o.Processed {
+		writeError(w, http.StatusBadRequest, "order "+id.String()+" is processed")
+		return nil, false
+	}
+	return o, true
+}
+
+// openOrdersLocked checks every order with openOrderLocked before any is changed.
+func (s *Server) openOrdersLocked(w http.ResponseWriter, ids []strfmt.UUID) ([]*ordermodels.OrderDetails, bool) {
+	if len(ids) == 0 {
+		writeError(w, http.StatusBadRequest, "orderIds must contain at least one value")
+		return nil, false
+	}
+	out := make([]*ordermodels.OrderDetails, 0, len(ids))
+	for _, id := range ids {
+		o, ok := s.openOrderLocked(w, id)
+		if !ok {
+			return nil, false
+		}
+		out = append(out, o)
+	}
+	return out, true
+}
+
+// linkItemsLocked points open order lines with the channel SKU of the source at the stock item.
+func (s *Server) linkItemsLocked(source, subSource, sku string, stockItemID strfmt.UUID) {
+	unlinked := false
+	for _, id := range s.orderSeq {
+		o := s.orders[id]
+		if o.Processed || o.GeneralInfo == nil || o.GeneralInfo.Source != source || o.GeneralInfo.SubSource != subSource {
+			continue
+		}
+		for _, item := range o.Items {
+			if item.ChannelSKU == sku {
+				item.ItemID, item.StockItemID, item.IsUnlinked = stockItemID, stockItemID, &unlinked
+			}
+		}
+	}
+}
+
+func itemIndex(o *ordermodels.OrderDetails, rowID strfmt.UUID) int {
+	for i, item := range o.Items {
+		if item.RowID == rowID {
+			return i
+		}
+	}
+	return -1
+}
+
+// recalcTotals recomputes line costs and order totals the simple way: prices include tax and
+// line discounts are percentages.
+func recalcTotals(o *ordermodels.OrderDetails) {
+	if o.TotalsInfo == nil {
+		o.TotalsInfo = &ordermodels.OrderTotalsInfo{}
+	}
+	var subtotal float64
+	for _, item := range o.Items {
+		item.CostIncTax = float64(item.Quantity) * item.PricePerUnit * (1 - item.Discount/100)
+		subtotal += item.CostIncTax
+	}
+	o.TotalsInfo.Subtotal = subtotal
+	o.TotalsInfo.TotalCharge = subtotal + o.TotalsInfo.PostageCost - o.TotalsInfo.TotalDiscount
+	if o.GeneralInfo != nil {
+		o.GeneralInfo.NumItems = int32(len(o.Items))
+	}
+}
+
+func fromChannelOrder(co *ordermodels.ChannelOrder) *ordermodels.OrderDetails {
+	o := &ordermodels.OrderDetails{
+		GeneralInfo: &ordermodels.OrderGeneralInfo{
+			ReferenceNum:         co.ReferenceNumber,
+			SecondaryReference:   co.SecondaryReferenceNumber,
+			ExternalReferenceNum: co.ExternalReference,
+			Source:               co.Source,
+			SubSource:            co.SubSource,
+			ReceivedDate:         co.ReceivedDate,
+			DespatchByDate:       co.DispatchBy,
+			IsParked:             co.OrderState == ordermodels.ChannelOrderOrderStatePark || co.PaymentStatus == ordermodels.ChannelOrderPaymentStatusUnpaid,
+			HoldOrCancel:         co.OrderState == ordermodels.ChannelOrderOrderStateHold,
+		},
+		CustomerInfo: &ordermodels.OrderCustomerInfo{
+			ChannelBuyerName: co.ChannelBuyerName,
+			Address:          fromChannelAddress(co.DeliveryAddress),
+			BillingAddress:   fromChannelAddress(co.BillingAddress),
+		},
+		ShippingInfo: &ordermodels.OrderShippingInfo{
+			PostalServiceName: co.PostalServiceName,
+			PostageCost:       co.PostalServiceCost,
+		},
+		TotalsInfo: &ordermodels.OrderTotalsInfo{
+			Currency:       co.Currency,
+			ConversionRate: co.ConversionRate,
+			PaymentMethod:  co.PaymentMethodName,
+			PostageCost:    co.PostalServiceCost,
+			TotalDiscount:  co.Discount,
+		},
+	}
+	for _, ci := range co.OrderItems {
+		o.Items = append(o.Items, &ordermodels.OrderItem{
+			ItemNumber:       ci.ItemNumber,
+			ChannelSKU:       ci.ChannelSKU,
+			SKU:              ci.ChannelSKU,
+			ChannelTitle:     ci.ItemTitle,
+			Title:            ci.ItemTitle,
+			Quantity:         ci.Qty,
+			PricePerUnit:     ci.PricePerUnit,
+			Discount:         ci.LineDiscount,
+			TaxRate:          ci.TaxRate,
+			TaxCostInclusive: ci.TaxCostInclusive,
+			IsService:        ci.IsService,
+		})
+	}
+	recalcTotals(o)
+	for _, n := range co.Notes {
+		o.Notes = append(o.Notes, &ordermodels.OrderNote{Note: n.Note, Internal: n.Internal, NoteDate: n.NoteEntryDate, CreatedBy: n.NoteUserName})
+	}
+	for _, p := range co.ExtendedProperties {
+		o.ExtendedProperties = append(o.ExtendedProperties, &ordermodels.ExtendedProperty{Name: p.Name, Type: p.Type, Value: p.Value})
+	}
+	return o
+}
+
+func fromChannelAddress(a *ordermodels.ChannelAddress) *ordermodels.CustomerAddress {
+	if a == nil {
+		return nil
+	}
+	country := a.Country
+	if country == "" {
+		country = a.MatchCountryCode
+	}
+	return &ordermodels.CustomerAddress{
+		FullName:     a.FullName,
+		Company:      a.Company,
+		Address1:     a.Address1,
+		Address2:     a.Address2,
+		Address3:     a.Address3,
+		Town:         a.Town,
+		Region:       a.Region,
+		PostCode:     a.PostCode,
+		Country:      country,
+		EmailAddress: a.EmailAddress,
+		PhoneNumber:  a.PhoneNumber,
+	}
+}
+
+func toOpenOrder(o *ordermodels.OrderDetails) *ordermodels.OpenOrder {
+	return &ordermodels.OpenOrder{
+		OrderID:      o.OrderID,
+		NumOrderID:   o.NumOrderID,
+		GeneralInfo:  o.GeneralInfo,
+		CustomerInfo: o.CustomerInfo,
+		ShippingInfo: o.ShippingInfo,
+		TotalsInfo:   o.TotalsInfo,
+		Items:        o.Items,
+		FolderName:   o.FolderName,
+	}
+}
@@ -0,0 +1,340 @@
+// Package lwmock provides programmable fakes of the Linnworks API branches.
+//
+// The fakes run the real request builders, so validation behaves as in production,
+// but DoJSON is answered by typed stubs instead of HTTP:
+//
+//	m := lwmock.New()
+//	m.OnGetOrdersById(func(req *models.OrdersGetOrdersByIDRequest) ([]models.OrderDetails, error) {
+//		return []models.OrderDetails{{NumOrderID: 1001}}, nil
+//	})
+//	runJob(ctx, m) // takes lw_api.API
+//	var sent models.OrdersGetOrdersByIDRequest
+//	m.LastCall("/api/Orders/GetOrdersById").Decode(&sent)
+package lwmock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/go-openapi/strfmt"
+
+	lw_api "github.com/MMC-BK/lw-api"
+	"github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/inventory"
+	inventorymodels "github.com/MMC-BK/lw-api/inventory/models"
+	"github.com/MMC-BK/lw-api/orders"
+	ordermodels "github.com/MMC-BK/lw-api/orders/models"
+	"github.com/MMC-BK/lw-api/processedorders"
+	processedmodels "github.com/MMC-BK/lw-api/processedorders/models"
+)
+
+// ErrNotStubbed is returned for calls to endpoints without a stub.
+var ErrNotStubbed = errors.New("lwmock: endpoint is not stubbed")
+
+// Call is one request the builders sent.
+type Call struct {
+	Method string
+	Path   string
+	Query  url.Values
+	// Body is the JSON payload, nil for requests without a body.
+	Body json.RawMessage
+}
+
+// Decode unmarshals the request payload into v.
+func (c Call) Decode(v any) error {
+	if c.Body == nil {
+		return errors.New("lwmock: call has no body")
+	}
+	return json.Unmarshal(c.Body, v)
+}
+
+// Handler answers a call. The returned value is JSON round-tripped into the builder's output.
+type Handler func(ctx context.Context, call Call) (any, error)
+
+// Client is a client.MakeRequest that records calls and dispatches them to stubs by path.
+type Client struct {
+	mu       sync.Mutex
+	handlers map[string]Handler
+	calls    []Call
+}
+
+var _ client.MakeRequest = (*Client)(nil)
+
+func NewClient() *Client {
+	return &Client{handlers: make(map[string]Handler)}
+}
+
+// On registers h for path, replacing a previous stub.
+func (c *Client) On(path string, h Handler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handlers[path] = h
+}
+
+func (c *Client) DoJSON(ctx context.Context, method, path string, query url.Values, in any, out any) error {
+	call := Call{Method: method, Path: path, Query: query}
+	if in != nil {
+		body, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		call.Body = body
+	}
+	c.mu.Lock()
+	c.calls = append(c.calls, call)
+	h, ok := c.handlers[path]
+	c.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s %s", ErrNotStubbed, method, path)
+	}
+
+	resp, err := h(ctx, call)
+	if err != nil {
+		return err
+	}
+	if out == nil || resp == nil {
+		return nil
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// Calls returns the recorded calls to path, or every call when path is empty.
+func (c *Client) Calls(path string) []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []Call
+	for _, call := range c.calls {
+		if path == "" || call.Path == path {
+			out = append(out, call)
+		}
+	}
+	return out
+}
+
+// LastCall returns the most recent call to path, or a zero Call.
+func (c *Client) LastCall(path string) Call {
+	calls := c.Calls(path)
+	if len(calls) == 0 {
+		return Call{}
+	}
+	return calls[len(calls)-1]
+}
+
+// Reset drops recorded calls and keeps the stubs.
+func (c *Client) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = nil
+}
+
+// API implements lw_api.API on top of a mock Client.
+type API struct {
+	*orders.Orders
+	*processedorders.ProcessedOrders
+	*inventory.Inventory
+	*Client
+}
+
+var _ lw_api.API = (*API)(nil)
+
+func New() *API {
+	c := NewClient()
+	return &API{
+		Orders:          orders.NewOrders(c),
+		ProcessedOrders: processedorders.NewProcessedOrders(c),
+		Inventory:       inventory.NewInventory(c),
+		Client:          c,
+	}
+}
+
+// stub decodes the payload into Req and passes it to fn.
+func stub[Req, Resp any](fn func(*Req) (Resp, error)) Handler {
+	return func(_ context.Context, call Call) (any, error) {
+		var req Req
+		if call.Body != nil {
+			if err := call.Decode(&req); err != nil {
+				return nil, err
+			}
+		}
+		return fn(&req)
+	}
+}
+
+func (m *API) OnGetOrdersById(fn func(req *ordermodels.OrdersGetOrdersByIDRequest) ([]ordermodels.OrderDetails, error)) {
+	m.On("/api/Orders/GetOrdersById", stub(fn))
+}
+
+func (m *API) OnGetOrderDetailsByNumOrderId(fn func(orderID int32) (*ordermodels.OrderDetails, error)) {
+	m.On("/api/Orders/GetOrderDetailsByNumOrderId", func(_ context.Context, call Call) (any, error) {
+		id, err := strconv.ParseInt(call.Query.Get("OrderId"), 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		return fn(int32(id))
+	})
+}
+
+func (m *API) OnGetOpenOrders(fn func(req *ordermodels.OrdersGetOpenOrdersRequest) (*ordermodels.GenericPagedResultOpenOrder, error)) {
+	m.On("/api/Orders/GetOpenOrders", stub(fn))
+}
+
+func (m *API) OnCreateOrders(fn func(req *ordermodels.OrdersCreateOrdersRequest) ([]strfmt.UUID, error)) {
+	m.On("/api/Orders/CreateOrders", stub(fn))
+}
+
+func (m *API) OnCreateNewOrder(fn func(req *ordermodels.OrdersCreateNewOrderRequest) (*ordermodels.OpenOrder, error)) {
+	m.On("/api/Orders/CreateNewOrder", stub(fn))
+}
+
+func (m *API) OnProcessOrder(fn func(req *ordermodels.OrdersProcessOrderRequest) (*ordermodels.ProcessOrderResult, error)) {
+	m.On("/api/Orders/ProcessOrder", stub(fn))
+}
+
+// OnProcessOrdersInBatch also serves ProcessMany, once per batch.
+func (m *API) OnProcessOrdersInBatch(fn func(req *ordermodels.OrdersProcessOrdersInBatchRequest) ([]ordermodels.ProcessOrderResult, error)) {
+	m.On("/api/Orders/ProcessOrdersInBatch", stub(fn))
+}
+
+func (m *API) OnProcessOrderByOrderOrReferenceId(fn func(req *ordermodels.ProcessOrderByOrderIDOrReferenceRequest) (*ordermodels.ProcessOrderByOrderIDOrReferenceResponse, error)) {
+	m.On("/api/Orders/ProcessOrderByOrderOrReferenceId", stub(func(req *ordermodels.OrdersProcessOrderByOrderOrReferenceIDRequest) (*ordermodels.ProcessOrderByOrderIDOrReferenceResponse, error) {
+		if req.Request == nil {
+			req.Request = &ordermodels.ProcessOrderByOrderIDOrReferenceRequest{}
+		}
+		return fn(req.Request)
+	}))
+}
+
+func (m *API) OnProcessOrderRequiredBatchScans(fn func(req *ordermodels.BatchAssignmentForOrderItems) (*ordermodels.ProcessOrderResult, error)) {
+	m.On("/api/Orders/ProcessOrder_RequiredBatchScans", stub(func(req *ordermodels.OrdersProcessOrderRequiredBatchScansRequest) (*ordermodels.ProcessOrderResult, error) {
+		if req.BatchAssignment == nil {
+			req.BatchAssignment = &ordermodels.BatchAssignmentForOrderItems{}
+		}
+		return fn(req.BatchAssignment)
+	}))
+}
+
+func (m *API) OnProcessFulfilmentCentreOrder(fn func(req *ordermodels.OrdersProcessFulfilmentCentreOrderRequest) (*ordermodels.ProcessOrderResult, error)) {
+	m.On("/api/Orders/ProcessFulfilmentCentreOrder", stub(fn))
+}
+
+func (m *API) OnAddOrderItem(fn func(req *ordermodels.OrdersAddOrderItemRequest) (*ordermodels.UpdateOrderItemResult, error)) {
+	m.On("/api/Orders/AddOrderItem", stub(fn))
+}
+
+func (m *API) OnRemoveOrderItem(fn func(req *ordermodels.OrdersRemoveOrderItemRequest) (*ordermodels.UpdateTotalsResult, error)) {
+	m.On("/api/Orders/RemoveOrderItem", stub(fn))
+}
+
+func (m *API) OnUpdateOrderItem(fn func(req *ordermodels.OrdersUpdateOrderItemRequest) (*ordermodels.UpdateTotalsResult, error)) {
+	m.On("/api/Orders/UpdateOrderItem", stub(fn))
+}
+
+func (m *API) OnUpdateLinkItem(fn func(req *ordermodels.OrdersUpdateLinkItemRequest) error) {
+	m.On("/api/Orders/UpdateLinkItem", stub(func(req *ordermodels.OrdersUpdateLinkItemRequest) (any, error) {
+		return nil, fn(req)
+	}))
+}
+
+func (m *API) OnCreateNewItemAndLink(fn func(req *ordermodels.OrdersCreateNewItemAndLinkRequest) error) {
+	m.On("/api/Orders/CreateNewItemAndLink", stub(func(req *ordermodels.OrdersCreateNewItemAndLinkRequest) (any, error) {
+		return nil, fn(req)
+	}))
+}
+
+func (m *API) OnGetCountries(fn func() ([]ordermodels.OrderCountry, error)) {
+	m.On("/api/Orders/GetCountries", func(context.Context, Call) (any, error) {
+		return fn()
+	})
+}
+
+func (m *API) OnSetOrderCustomerInfo(fn func(req *ordermodels.OrdersSetOrderCustomerInfoRequest) error) {
+	m.On("/api/Orders/SetOrderCustomerInfo", stub(func(req *ordermodels.OrdersSetOrderCustomerInfoRequest) (any, error) {
+		return nil, fn(req)
+	}))
+}
+
+func (m *API) OnSetOrderGeneralInfo(fn func(req *ordermodels.OrdersSetOrderGeneralInfoRequest) error) {
+	m.On("/api/Orders/SetOrderGeneralInfo", stub(func(req *ordermodels.OrdersSetOrderGeneralInfoRequest) (any, error) {
+		return nil, fn(req)
+	}))
+}
+
+func (m *API) OnSetOrderShippingInfo(fn func(req *ordermodels.OrdersSetOrderShippingInfoRequest) (*ordermodels.UpdateTotalsResult, error)) {
+	m.On("/api/Orders/SetOrderShippingInfo", stub(fn))
+}
+
+func (m *API) OnSetOrderTotalsInfo(fn func(req *ordermodels.OrdersSetOrderTotalsInfoRequest) error) {
+	m.On("/api/Orders/SetOrderTotalsInfo", stub(func(req *ordermodels.OrdersSetOrderTotalsInfoRequest) (any, error) {
+		return nil, fn(req)
+	}))
+}
+
+func (m *API) OnUpdateBillingAddress(fn func(req *ordermodels.OrdersUpdateBillingAddressRequest) error) {
+	m.On("/api/Orders/UpdateBillingAddress", stub(func(req *ordermodels.OrdersUpdateBillingAddressRequest) (any, error) {
+		return nil, fn(req)
+	}))
+}
+
+func (m *API) OnChangeShippingMethod(fn func(req *ordermodels.OrdersChangeShippingMethodRequest) error) {
+	m.On("/api/Orders/ChangeShippingMethod", stub(func(req *ordermodels.OrdersChangeShippingMethodRequest) (any, error) {
+		return nil, fn(req)
+	}))
+}
+
+func (m *API) OnLockOrder(fn func(req *ordermodels.OrdersLockOrderRequest) error) {
+	m.On("/api/Orders/LockOrder", stub(func(req *ordermodels.OrdersLockOrderRequest) (any, error) {
+		return nil, fn(req)
+	}))
+}
+
+func (m *API) OnCancelOrder(fn func(req *ordermodels.OrdersCancelOrderRequest) error) {
+	m.On("/api/Orders/CancelOrder", stub(func(req *ordermodels.OrdersCancelOrderRequest) (any, error) {
+		return nil, fn(req)
+	}))
+}
+
+func (m *API) OnDeleteOrder(fn func(req *ordermodels.OrdersDeleteOrderRequest) error) {
+	m.On("/api/Orders/DeleteOrder", stub(func(req *ordermodels.OrdersDeleteOrderRequest) (any, error) {
+		return nil, fn(req)
+	}))
+}
+
+func (m *API) OnChangeStatus(fn func(req *ordermodels.OrdersChangeStatusRequest) error) {
+	m.On("/api/Orders/ChangeStatus", stub(func(req *ordermodels.OrdersChangeStatusRequest) (any, error) {
+		return nil, fn(req)
+	}))
+}
+
+func (m *API) OnCompleteOrder(fn func(req *ordermodels.OrdersCompleteOrderRequest) error) {
+	m.On("/api/Orders/CompleteOrder", stub(func(req *ordermodels.OrdersCompleteOrderRequest) (any, error) {
+		return nil, fn(req)
+	}))
+}
+
+func (m *API) OnSearchProcessedOrders(fn func(req *processedmodels.SearchProcessedOrdersRequest) (*processedmodels.GenericPagedResultProcessedOrderWeb, error)) {
+	m.On("/api/ProcessedOrders/SearchProcessedOrders", stub(func(req *processedmodels.ProcessedOrdersSearchProcessedOrdersRequest) (*processedmodels.SearchProcessedOrdersResponse, error) {
+		if req.Request == nil {
+			req.Request = &processedmodels.SearchProcessedOrdersRequest{}
+		}
+		page, err := fn(req.Request)
+		if err != nil {
+			return nil, err
+		}
+		return &processedmodels.SearchProcessedOrdersResponse{ProcessedOrders: page}, nil
+	}))
+}
+
+func (m *API) OnGetStockLocations(fn func() ([]inventorymodels.StockLocation, error)) {
+	m.On("/api/Inventory/GetStockLocations", func(context.Context, Call) (any, error) {
+		return fn()
+	})
+}
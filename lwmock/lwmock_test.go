@@ -0,0 +1,127 @@
+package lwmock_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+
+	lw_api "github.com/MMC-BK/lw-api"
+	"github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/lwmock"
+	ordermodels "github.com/MMC-BK/lw-api/orders/models"
+	processedmodels "github.com/MMC-BK/lw-api/processedorders/models"
+)
+
+const orderID = strfmt.UUID("0b8f7d2e-4b1a-4c55-9d0e-3c2a1f6b7e11")
+
+func referenceOf(ctx context.Context, api lw_api.API, id strfmt.UUID) (string, error) {
+	out, err := api.GetOrdersById(ctx).PkOrderIds([]strfmt.UUID{id}).Do()
+	if err != nil {
+		return "", err
+	}
+	return out[0].GeneralInfo.ReferenceNum, nil
+}
+
+func TestAPI(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return stubbed response and record payload", func(t *testing.T) {
+		m := lwmock.New()
+		m.OnGetOrdersById(func(req *ordermodels.OrdersGetOrdersByIDRequest) ([]ordermodels.OrderDetails, error) {
+			return []ordermodels.OrderDetails{{OrderID: req.PkOrderIds[0], GeneralInfo: &ordermodels.OrderGeneralInfo{ReferenceNum: "REF-1"}}}, nil
+		})
+
+		ref, err := referenceOf(ctx, m, orderID)
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if ref != "REF-1" {
+			t.Errorf("Expected REF-1, got %q", ref)
+		}
+
+		var sent ordermodels.OrdersGetOrdersByIDRequest
+		if err := m.LastCall("/api/Orders/GetOrdersById").Decode(&sent); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if len(sent.PkOrderIds) != 1 || sent.PkOrderIds[0] != orderID {
+			t.Errorf("unexpected payload %+v", sent)
+		}
+	})
+
+	t.Run("should serve DoRaw from stubs", func(t *testing.T) {
+		m := lwmock.New()
+		m.OnGetOrdersById(func(req *ordermodels.OrdersGetOrdersByIDRequest) ([]ordermodels.OrderDetails, error) {
+			return []ordermodels.OrderDetails{{NumOrderID: 1001}}, nil
+		})
+		resp, err := m.GetOrdersById(ctx).PkOrderIds([]strfmt.UUID{orderID}).DoRaw()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		var out []ordermodels.OrderDetails
+		if err := resp.Decode(&out); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if len(out) != 1 || out[0].NumOrderID != 1001 {
+			t.Errorf("unexpected response %s", resp.Body)
+		}
+	})
+
+	t.Run("should pass stubbed errors through", func(t *testing.T) {
+		m := lwmock.New()
+		m.OnGetOrderDetailsByNumOrderId(func(int32) (*ordermodels.OrderDetails, error) {
+			return nil, &client.APIError{Status: http.StatusNotFound}
+		})
+		_, err := m.GetOrderDetailsByNumOrderId(ctx).OrderID(7).Do()
+		if !client.IsNotFound(err) {
+			t.Errorf("Expected not found error, got %v", err)
+		}
+		if got := m.LastCall("/api/Orders/GetOrderDetailsByNumOrderId").Query.Get("OrderId"); got != "7" {
+			t.Errorf("Expected OrderId=7, got %q", got)
+		}
+	})
+
+	t.Run("should fail unstubbed calls", func(t *testing.T) {
+		m := lwmock.New()
+		_, err := m.GetStockLocations(ctx).Do()
+		if !errors.Is(err, lwmock.ErrNotStubbed) {
+			t.Errorf("Expected ErrNotStubbed, got %v", err)
+		}
+	})
+
+	t.Run("should not call the stub when the builder is invalid", func(t *testing.T) {
+		m := lwmock.New()
+		m.OnGetOrdersById(func(*ordermodels.OrdersGetOrdersByIDRequest) ([]ordermodels.OrderDetails, error) {
+			return nil, nil
+		})
+		if _, err := m.GetOrdersById(ctx).PkOrderIds(nil).Do(); err == nil {
+			t.Error("Expected validation error, got nil")
+		}
+		if n := len(m.Calls("")); n != 0 {
+			t.Errorf("Expected no calls, got %d", n)
+		}
+	})
+
+	t.Run("should page through stubbed results", func(t *testing.T) {
+		m := lwmock.New()
+		m.OnSearchProcessedOrders(func(req *processedmodels.SearchProcessedOrdersRequest) (*processedmodels.GenericPagedResultProcessedOrderWeb, error) {
+			return &processedmodels.GenericPagedResultProcessedOrderWeb{
+				Data:       []*processedmodels.ProcessedOrderWeb{{NOrderID: req.PageNumber}},
+				PageNumber: req.PageNumber,
+				TotalPages: 3,
+			}, nil
+		})
+		n := 0
+		for _, err := range m.SearchProcessedOrders(ctx).ResultsPerPage(1).Pager().All(ctx) {
+			if err != nil {
+				t.Fatalf("Expected nil error, got %v", err)
+			}
+			n++
+		}
+		if n != 3 {
+			t.Errorf("Expected 3 orders, got %d", n)
+		}
+	})
+}
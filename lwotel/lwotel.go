@@ -0,0 +1,140 @@
+// Package lwotel adds OpenTelemetry tracing and metrics to the Linnworks client transport chain.
+package lwotel
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/MMC-BK/lw-api/client"
+)
+
+const instrumentationName = "github.com/MMC-BK/lw-api/lwotel"
+
+type config struct {
+	tp     trace.TracerProvider
+	mp     metric.MeterProvider
+	tenant string
+}
+
+type Option func(*config)
+
+// WithTracerProvider overrides the global tracer provider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.tp = tp }
+}
+
+// WithMeterProvider overrides the global meter provider.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) { c.mp = mp }
+}
+
+// WithTenant tags spans and metrics with a tenant id, e.g. lw_api.TenantID(token).
+func WithTenant(tenant string) Option {
+	return func(c *config) { c.tenant = tenant }
+}
+
+// Middleware creates a client span per Linnworks call named after the endpoint path and
+// records request duration and error metrics. Register it outside the rate limit and retry
+// middlewares (LinnworksAPIBuilder.Middleware does that) so one span covers all attempts.
+func Middleware(opts ...Option) (client.RTMiddleware, error) {
+	cfg := config{tp: otel.GetTracerProvider(), mp: otel.GetMeterProvider()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	tracer := cfg.tp.Tracer(instrumentationName)
+	meter := cfg.mp.Meter(instrumentationName)
+
+	duration, err := meter.Float64Histogram("lw.client.request.duration",
+		metric.WithDescription("Duration of Linnworks API calls including retries and rate limit waits"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	errorsCounter, err := meter.Int64Counter("lw.client.request.errors",
+		metric.WithDescription("Linnworks API calls that failed with a transport error or a non-2xx status"),
+		metric.WithUnit("{call}"))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return client.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			attrs := []attribute.KeyValue{
+				attribute.String("http.request.method", req.Method),
+				attribute.String("url.path", req.URL.Path),
+			}
+			if cfg.tenant != "" {
+				attrs = append(attrs, attribute.String("lw.tenant", cfg.tenant))
+			}
+
+			ctx, stats := client.WithCallStats(req.Context())
+			ctx, span := tracer.Start(ctx, req.URL.Path,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...))
+			defer span.End()
+
+			resp, err := next.RoundTrip(req.WithContext(ctx))
+
+			span.SetAttributes(
+				attribute.Int("lw.retry.attempts", stats.Attempts()),
+				attribute.Float64("lw.ratelimit.wait_ms", float64(stats.RateLimitWait())/float64(time.Millisecond)),
+			)
+			failed := err != nil
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				attrs = append(attrs, attribute.String("error.type", "transport"))
+			} else {
+				attrs = append(attrs, attribute.Int("http.response.status_code", resp.StatusCode))
+				span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+				if resp.StatusCode >= 400 {
+					failed = true
+					span.SetStatus(codes.Error, resp.Status)
+					attrs = append(attrs, attribute.String("error.type", http.StatusText(resp.StatusCode)))
+				}
+			}
+
+			set := metric.WithAttributes(attrs...)
+			duration.Record(ctx, time.Since(start).Seconds(), set)
+			if failed {
+				errorsCounter.Add(ctx, 1, set)
+			}
+			return resp, err
+		})
+	}, nil
+}
+
+// DriftCounter returns a client.StrictDecoding OnDrift callback that counts schema drift in
+// lw.client.schema.drift, tagged with the endpoint, drift kind and field path.
+func DriftCounter(opts ...Option) (func(client.Drift), error) {
+	cfg := config{mp: otel.GetMeterProvider()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	counter, err := cfg.mp.Meter(instrumentationName).Int64Counter("lw.client.schema.drift",
+		metric.WithDescription("Response fields that do not match the generated models"),
+		metric.WithUnit("{field}"))
+	if err != nil {
+		return nil, err
+	}
+	return func(d client.Drift) {
+		attrs := []attribute.KeyValue{
+			attribute.String("http.request.method", d.Method),
+			attribute.String("url.path", d.Endpoint),
+			attribute.String("lw.drift.kind", string(d.Kind)),
+			attribute.String("lw.drift.field", d.Field),
+		}
+		if cfg.tenant != "" {
+			attrs = append(attrs, attribute.String("lw.tenant", cfg.tenant))
+		}
+		counter.Add(context.Background(), 1, metric.WithAttributes(attrs...))
+	}, nil
+}
@@ -0,0 +1,129 @@
+package lwotel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/MMC-BK/lw-api/client"
+)
+
+func TestMiddleware(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	mw, err := Middleware(WithTracerProvider(tp), WithMeterProvider(mp), WithTenant("tenant-a"))
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	policy := client.RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	c, err := client.NewClient(client.WithBaseURL(srv.URL), client.WithTransportChain(
+		mw,
+		client.RateLimitMiddleware(10, time.Second),
+		client.RetryPolicyMiddleware(policy),
+	))
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "service")
+	if err := c.DoJSON(ctx, http.MethodGet, "/api/Inventory/GetStockLocations", nil, nil, nil); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	parent.End()
+
+	var span *tracetest.SpanStub
+	for i, s := range exporter.GetSpans() {
+		if s.Name == "/api/Inventory/GetStockLocations" {
+			span = &exporter.GetSpans()[i]
+		}
+	}
+	if span == nil {
+		t.Fatal("Expected a span named after the endpoint path")
+	}
+	if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Error("Expected the call span to nest under the caller span")
+	}
+	attrs := attribute.NewSet(span.Attributes...)
+	if v, _ := attrs.Value("lw.retry.attempts"); v.AsInt64() != 2 {
+		t.Errorf("Expected 2 attempts, got %v", v.AsInt64())
+	}
+	if v, _ := attrs.Value("http.response.status_code"); v.AsInt64() != http.StatusOK {
+		t.Errorf("Expected status 200, got %v", v.AsInt64())
+	}
+	if v, _ := attrs.Value("lw.tenant"); v.AsString() != "tenant-a" {
+		t.Errorf("Expected tenant-a, got %q", v.AsString())
+	}
+	if _, ok := attrs.Value("lw.ratelimit.wait_ms"); !ok {
+		t.Error("Expected rate limit wait attribute")
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	found := false
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if h, ok := m.Data.(metricdata.Histogram[float64]); ok && m.Name == "lw.client.request.duration" {
+				found = len(h.DataPoints) == 1 && h.DataPoints[0].Count == 1
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected one request duration observation")
+	}
+}
+
+func TestDriftCounter(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	onDrift, err := DriftCounter(WithMeterProvider(mp))
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	onDrift(client.Drift{Method: http.MethodPost, Endpoint: "/api/Orders/GetOrdersById", Kind: client.DriftUnknownField, Field: "$[].NewField"})
+	onDrift(client.Drift{Method: http.MethodPost, Endpoint: "/api/Orders/GetOrdersById", Kind: client.DriftUnknownField, Field: "$[].NewField"})
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	var total int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if s, ok := m.Data.(metricdata.Sum[int64]); ok && m.Name == "lw.client.schema.drift" {
+				for _, dp := range s.DataPoints {
+					if v, _ := dp.Attributes.Value("lw.drift.field"); v.AsString() == "$[].NewField" {
+						total += dp.Value
+					}
+				}
+			}
+		}
+	}
+	if total != 2 {
+		t.Errorf("Expected 2 drift observations, got %d", total)
+	}
+}
@@ -0,0 +1,34 @@
+package lw_api
+
+import (
+	"time"
+
+	client2 "github.com/MMC-BK/lw-api/client"
+)
+
+// CallOption tunes a single builder call, see the builders' WithOptions:
+//
+//	api.GetOrdersById(ctx).PkOrderIds(ids).WithOptions(lw_api.Timeout(5*time.Second), lw_api.NoRetry()).Do()
+type CallOption = client2.CallOption
+
+// Rate limiter priorities for Priority.
+const (
+	PriorityLow    = client2.PriorityLow
+	PriorityNormal = client2.PriorityNormal
+	PriorityHigh   = client2.PriorityHigh
+)
+
+// Timeout bounds the whole call including retries.
+func Timeout(d time.Duration) CallOption { return client2.CallTimeout(d) }
+
+// NoRetry disables retries for the call.
+func NoRetry() CallOption { return client2.CallNoRetry() }
+
+// Retry replaces the client retry policy for the call.
+func Retry(policy client2.RetryPolicy) CallOption { return client2.CallRetryPolicy(policy) }
+
+// Header sets a request header for the call.
+func Header(key, value string) CallOption { return client2.CallHeader(key, value) }
+
+// Priority lets interactive calls jump ahead of background jobs in the rate limiter.
+func Priority(p client2.Priority) CallOption { return client2.CallPriority(p) }
@@ -0,0 +1,97 @@
+package orders
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+func (b *AddOrderItemRequestBuilder) Quantity(qty int32) *AddOrderItemRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	if qty <= 0 {
+		b.err = append(b.err, errors.New("quantity must be greater than 0"))
+		return b
+	}
+	b.data.Quantity = qty
+	return b
+}
+
+func (b *AddOrderItemRequestBuilder) CreatedDate(t time.Time) *AddOrderItemRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	if t.IsZero() {
+		b.err = append(b.err, errors.New("createdDate must not be zero"))
+		return b
+	}
+	b.data.CreatedDate = strfmt.DateTime(t)
+	return b
+}
+
+// PricePerUnit, DiscountPercentage, TaxRatePercentage and TaxInclusive fill the line pricing;
+// without them Linnworks prices the line from the inventory item.
+func (b *AddOrderItemRequestBuilder) PricePerUnit(price float64) *AddOrderItemRequestBuilder {
+	if price < 0 {
+		return b.pricingError(errors.New("pricePerUnit must not be negative"))
+	}
+	return b.pricing(func(p *models.LinePricingRequest) { p.PricePerUnit = price })
+}
+
+func (b *AddOrderItemRequestBuilder) DiscountPercentage(pct float64) *AddOrderItemRequestBuilder {
+	if pct < 0 || pct > 100 {
+		return b.pricingError(errors.New("discountPercentage must be between 0 and 100"))
+	}
+	return b.pricing(func(p *models.LinePricingRequest) { p.DiscountPercentage = pct })
+}
+
+func (b *AddOrderItemRequestBuilder) TaxRatePercentage(pct float64) *AddOrderItemRequestBuilder {
+	if pct < 0 || pct > 100 {
+		return b.pricingError(errors.New("taxRatePercentage must be between 0 and 100"))
+	}
+	return b.pricing(func(p *models.LinePricingRequest) { p.TaxRatePercentage = pct })
+}
+
+func (b *AddOrderItemRequestBuilder) TaxInclusive(value bool) *AddOrderItemRequestBuilder {
+	return b.pricing(func(p *models.LinePricingRequest) { p.TaxInclusive = value })
+}
+
+func (b *AddOrderItemRequestBuilder) pricing(set func(*models.LinePricingRequest)) *AddOrderItemRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	if b.data.LinePricing == nil {
+		b.data.LinePricing = &models.LinePricingRequest{}
+	}
+	set(b.data.LinePricing)
+	return b
+}
+
+func (b *AddOrderItemRequestBuilder) pricingError(err error) *AddOrderItemRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	b.err = append(b.err, err)
+	return b
+}
+
+func (b *AddOrderItemRequestBuilder) requiredField() []error {
+	var errs []error
+	if b.data.FulfilmentCenter == "" {
+		errs = append(errs, errors.New("fulfilmentCenter is required"))
+	}
+	if b.data.ItemID == "" {
+		errs = append(errs, errors.New("itemId is required"))
+	}
+	if b.data.OrderID == "" {
+		errs = append(errs, errors.New("orderId is required"))
+	}
+	if b.data.Quantity == 0 {
+		errs = append(errs, errors.New("quantity is required"))
+	}
+	return errs
+}
@@ -0,0 +1,168 @@
+package orders
+
+import (
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+// AddressPatch holds the address fields to change; fields that are not set keep their current
+// value. Setting a field to "" clears it.
+type AddressPatch struct {
+	fullName, company            *string
+	address1, address2, address3 *string
+	town, region, postCode       *string
+	country, countryCode         *string
+	emailAddress, phoneNumber    *string
+	err                          []error
+}
+
+func NewAddressPatch() *AddressPatch {
+	return &AddressPatch{err: make([]error, 0)}
+}
+
+func (p *AddressPatch) FullName(value string) *AddressPatch { return p.set(&p.fullName, value) }
+
+func (p *AddressPatch) Company(value string) *AddressPatch { return p.set(&p.company, value) }
+
+func (p *AddressPatch) Address1(value string) *AddressPatch { return p.set(&p.address1, value) }
+
+func (p *AddressPatch) Address2(value string) *AddressPatch { return p.set(&p.address2, value) }
+
+func (p *AddressPatch) Address3(value string) *AddressPatch { return p.set(&p.address3, value) }
+
+func (p *AddressPatch) Town(value string) *AddressPatch { return p.set(&p.town, value) }
+
+func (p *AddressPatch) Region(value string) *AddressPatch { return p.set(&p.region, value) }
+
+func (p *AddressPatch) PostCode(value string) *AddressPatch { return p.set(&p.postCode, value) }
+
+func (p *AddressPatch) PhoneNumber(value string) *AddressPatch { return p.set(&p.phoneNumber, value) }
+
+// Country sets the country by its Linnworks name; the name is checked against GetCountries.
+func (p *AddressPatch) Country(name string) *AddressPatch {
+	if p == nil {
+		return nil
+	}
+	if name == "" {
+		p.err = append(p.err, fieldError("$.Country", "required", nil, "must not be empty"))
+		return p
+	}
+	p.countryCode = nil
+	return p.set(&p.country, name)
+}
+
+// CountryCode sets the country by ISO 3166-1 alpha-2 code, e.g. GB. The code is resolved to
+// the Linnworks country name and id with GetCountries.
+func (p *AddressPatch) CountryCode(code string) *AddressPatch {
+	if p == nil {
+		return nil
+	}
+	if !isUpperAlpha(code, 2) {
+		p.err = append(p.err, fieldError("$.CountryCode", "pattern", code, "must be a 2 letter ISO 3166-1 code"))
+		return p
+	}
+	p.country = nil
+	return p.set(&p.countryCode, code)
+}
+
+func (p *AddressPatch) EmailAddress(value string) *AddressPatch {
+	if p == nil {
+		return nil
+	}
+	if value != "" && !strfmt.IsEmail(value) {
+		p.err = append(p.err, fieldError("$.EmailAddress", "type", value, "must be an email address"))
+		return p
+	}
+	return p.set(&p.emailAddress, value)
+}
+
+func (p *AddressPatch) set(field **string, value string) *AddressPatch {
+	if p == nil {
+		return nil
+	}
+	*field = &value
+	return p
+}
+
+func (p *AddressPatch) needsCountries() bool {
+	return p != nil && (p.country != nil || p.countryCode != nil)
+}
+
+// apply writes the set fields over dst. Paths of the returned errors are relative to the address.
+func (p *AddressPatch) apply(dst *models.CustomerAddress, countries []models.OrderCountry) []error {
+	errs := make([]error, len(p.err))
+	copy(errs, p.err)
+	for _, f := range []struct {
+		from *string
+		to   *string
+	}{
+		{p.fullName, &dst.FullName},
+		{p.company, &dst.Company},
+		{p.address1, &dst.Address1},
+		{p.address2, &dst.Address2},
+		{p.address3, &dst.Address3},
+		{p.town, &dst.Town},
+		{p.region, &dst.Region},
+		{p.postCode, &dst.PostCode},
+		{p.emailAddress, &dst.EmailAddress},
+		{p.phoneNumber, &dst.PhoneNumber},
+	} {
+		if f.from != nil {
+			*f.to = *f.from
+		}
+	}
+	switch {
+	case p.countryCode != nil:
+		c, ok := findCountry(countries, func(c models.OrderCountry) bool { return c.CountryCode == *p.countryCode })
+		if !ok {
+			errs = append(errs, fieldError("$.CountryCode", "enum", *p.countryCode, "is not a Linnworks country"))
+			break
+		}
+		setCountry(dst, c)
+	case p.country != nil:
+		c, ok := findCountry(countries, func(c models.OrderCountry) bool { return strings.EqualFold(c.CountryName, *p.country) })
+		if !ok {
+			errs = append(errs, fieldError("$.Country", "enum", *p.country, "is not a Linnworks country"))
+			break
+		}
+		setCountry(dst, c)
+	}
+	return errs
+}
+
+func findCountry(countries []models.OrderCountry, match func(models.OrderCountry) bool) (models.OrderCountry, bool) {
+	for _, c := range countries {
+		if match(c) {
+			return c, true
+		}
+	}
+	return models.OrderCountry{}, false
+}
+
+func setCountry(dst *models.CustomerAddress, c models.OrderCountry) {
+	dst.Country = c.CountryName
+	dst.CountryID = c.CountryID
+	dst.Continent = c.Continent
+}
+
+// validateAddress checks the lines Linnworks needs to ship to an address. Linnworks replaces
+// the stored address as a whole, so a half-filled one would wipe the missing lines.
+func validateAddress(a *models.CustomerAddress) []error {
+	var errs []error
+	if a.FullName == "" && a.Company == "" {
+		errs = append(errs, fieldError("$.FullName", "required", nil, "FullName or Company is required"))
+	}
+	if a.Address1 == "" {
+		errs = append(errs, fieldError("$.Address1", "required", nil, "is required"))
+	}
+	if a.Country == "" && a.CountryID == "" {
+		errs = append(errs, fieldError("$.Country", "required", nil, "Country or CountryId is required"))
+	}
+	if a.EmailAddress != "" && !strfmt.IsEmail(a.EmailAddress) {
+		errs = append(errs, fieldError("$.EmailAddress", "type", a.EmailAddress, "must be an email address"))
+	}
+	return errs
+}
@@ -0,0 +1,45 @@
+package orders
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+)
+
+// OrdersAPI is the orders branch of the client. Depend on it instead of *Orders to swap in lwmock.
+type OrdersAPI interface {
+	GetOrdersById(ctx context.Context) *GetOrdersByIdRequestBuilder
+	GetOrderDetailsByNumOrderId(ctx context.Context) *GetOrderDetailsByNumOrderIdRequestBuilder
+	GetOpenOrders(ctx context.Context) *GetOpenOrdersRequestBuilder
+	GetOrderNotes(ctx context.Context) *GetOrderNotesRequestBuilder
+	CreateOrders(ctx context.Context) *CreateOrdersRequestBuilder
+	CreateNewOrder(ctx context.Context) *CreateNewOrderRequestBuilder
+	ProcessOrder(ctx context.Context) *ProcessOrderRequestBuilder
+	ProcessOrdersInBatch(ctx context.Context) *ProcessOrdersInBatchRequestBuilder
+	ProcessOrderByOrderOrReferenceId(ctx context.Context) *ProcessOrderByOrderOrReferenceIdRequestBuilder
+	ProcessOrderRequiredBatchScans(ctx context.Context) *ProcessOrderRequiredBatchScansRequestBuilder
+	ProcessFulfilmentCentreOrder(ctx context.Context) *ProcessFulfilmentCentreOrderRequestBuilder
+	ProcessMany(ctx context.Context) *ProcessManyBuilder
+	AddOrderItem(ctx context.Context) *AddOrderItemRequestBuilder
+	RemoveOrderItem(ctx context.Context) *RemoveOrderItemRequestBuilder
+	UpdateOrderItem(ctx context.Context) *UpdateOrderItemRequestBuilder
+	UpdateLinkItem(ctx context.Context) *UpdateLinkItemRequestBuilder
+	CreateNewItemAndLink(ctx context.Context) *CreateNewItemAndLinkRequestBuilder
+	EditOrder(ctx context.Context, numOrderID int32) (*OrderEditor, error)
+	GetCountries(ctx context.Context) *GetCountriesRequestBuilder
+	SetOrderCustomerInfo(ctx context.Context) *SetOrderCustomerInfoRequestBuilder
+	SetOrderGeneralInfo(ctx context.Context) *SetOrderGeneralInfoRequestBuilder
+	SetOrderShippingInfo(ctx context.Context) *SetOrderShippingInfoRequestBuilder
+	SetOrderTotalsInfo(ctx context.Context) *SetOrderTotalsInfoRequestBuilder
+	UpdateBillingAddress(ctx context.Context) *UpdateBillingAddressRequestBuilder
+	ChangeShippingMethod(ctx context.Context) *ChangeShippingMethodRequestBuilder
+	PatchOrder(ctx context.Context, orderID strfmt.UUID) *OrderPatchBuilder
+	LockOrder(ctx context.Context) *LockOrderRequestBuilder
+	CancelOrder(ctx context.Context) *CancelOrderRequestBuilder
+	DeleteOrder(ctx context.Context) *DeleteOrderRequestBuilder
+	ChangeStatus(ctx context.Context) *ChangeStatusRequestBuilder
+	CompleteOrder(ctx context.Context) *CompleteOrderRequestBuilder
+	TransitionOrders(ctx context.Context) *OrderTransitionBuilder
+}
+
+var _ OrdersAPI = (*Orders)(nil)
@@ -0,0 +1,27 @@
+package orders
+
+import "errors"
+
+// Refund is the amount refunded to the customer with the cancellation.
+func (b *CancelOrderRequestBuilder) Refund(amount float64) *CancelOrderRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	if amount < 0 {
+		b.err = append(b.err, errors.New("refund must not be negative"))
+		return b
+	}
+	b.data.Refund = amount
+	return b
+}
+
+func (b *CancelOrderRequestBuilder) requiredField() []error {
+	var errs []error
+	if b.data.FulfilmentCenter == "" {
+		errs = append(errs, errors.New("fulfilmentCenter is required"))
+	}
+	if b.data.OrderID == "" {
+		errs = append(errs, errors.New("orderId is required"))
+	}
+	return errs
+}
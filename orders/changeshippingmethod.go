@@ -0,0 +1,14 @@
+package orders
+
+import "errors"
+
+func (b *ChangeShippingMethodRequestBuilder) requiredField() []error {
+	var errs []error
+	if len(b.data.OrderIds) == 0 {
+		errs = append(errs, errors.New("orderIds is required"))
+	}
+	if b.data.ShippingMethod == "" {
+		errs = append(errs, errors.New("shippingMethod is required"))
+	}
+	return errs
+}
@@ -0,0 +1,45 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	lw_api "github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+// statusUnset marks a ChangeStatus request without Status: OrderStatusUnpaid is 0 and cannot
+// be told apart from a missing value.
+const statusUnset int32 = -1
+
+func (o Orders) ChangeStatus(ctx context.Context) *ChangeStatusRequestBuilder {
+	return &ChangeStatusRequestBuilder{
+		ctx:    ctx,
+		client: o.c,
+		data:   &models.OrdersChangeStatusRequest{Status: statusUnset},
+		err:    make([]error, 0),
+	}
+}
+
+func (b *ChangeStatusRequestBuilder) build() (*models.OrdersChangeStatusRequest, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	errs := make([]error, len(b.err))
+	copy(errs, b.err)
+	if len(b.data.OrderIds) == 0 {
+		errs = append(errs, errors.New("orderIds must contain at least one value"))
+	}
+	switch status := b.data.Status; {
+	case status == statusUnset:
+		errs = append(errs, errors.New("status is required"))
+	case status < OrderStatusUnpaid || status > OrderStatusResend:
+		errs = append(errs, fmt.Errorf("status %d is not a known order status", status))
+	}
+	errs = append(errs, lw_api.ValidatePayload(b.ctx, b.data)...)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return b.data, nil
+}
@@ -0,0 +1,858 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+
+	lw_api "github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+// Errors of the channel order builders are *lw_api.FieldError with paths relative to the object
+// being built, e.g. $.OrderItems[1].Qty. CreateOrders re-roots them under $.orders[i].
+
+// ChannelOrderBuilder assembles models.ChannelOrder for CreateOrders.
+type ChannelOrderBuilder struct {
+	data     *models.ChannelOrder
+	items    []*ChannelOrderItemBuilder
+	billing  *ChannelAddressBuilder
+	delivery *ChannelAddressBuilder
+	notes    []*ChannelOrderNoteBuilder
+	err      []error
+}
+
+func NewChannelOrderBuilder() *ChannelOrderBuilder {
+	return &ChannelOrderBuilder{
+		data: &models.ChannelOrder{},
+		err:  make([]error, 0),
+	}
+}
+
+// ReferenceNumber is the channel order number; it should match the one used for despatch.
+func (b *ChannelOrderBuilder) ReferenceNumber(value string) *ChannelOrderBuilder {
+	return b.requiredString("$.ReferenceNumber", value, func(v string) { b.data.ReferenceNumber = v })
+}
+
+func (b *ChannelOrderBuilder) SecondaryReferenceNumber(value string) *ChannelOrderBuilder {
+	return b.requiredString("$.SecondaryReferenceNumber", value, func(v string) { b.data.SecondaryReferenceNumber = v })
+}
+
+func (b *ChannelOrderBuilder) ExternalReference(value string) *ChannelOrderBuilder {
+	return b.requiredString("$.ExternalReference", value, func(v string) { b.data.ExternalReference = v })
+}
+
+// Source and SubSource identify the channel integration, e.g. "MYSHOP" and "myshop.example".
+func (b *ChannelOrderBuilder) Source(value string) *ChannelOrderBuilder {
+	return b.requiredString("$.Source", value, func(v string) { b.data.Source = v })
+}
+
+func (b *ChannelOrderBuilder) SubSource(value string) *ChannelOrderBuilder {
+	return b.requiredString("$.SubSource", value, func(v string) { b.data.SubSource = v })
+}
+
+func (b *ChannelOrderBuilder) Site(value string) *ChannelOrderBuilder {
+	return b.requiredString("$.Site", value, func(v string) { b.data.Site = v })
+}
+
+func (b *ChannelOrderBuilder) ChannelBuyerName(value string) *ChannelOrderBuilder {
+	return b.requiredString("$.ChannelBuyerName", value, func(v string) { b.data.ChannelBuyerName = v })
+}
+
+func (b *ChannelOrderBuilder) BuyerTaxNumber(value string) *ChannelOrderBuilder {
+	return b.requiredString("$.BuyerTaxNumber", value, func(v string) { b.data.BuyerTaxNumber = v })
+}
+
+func (b *ChannelOrderBuilder) requiredString(path, value string, set func(string)) *ChannelOrderBuilder {
+	if b == nil {
+		return nil
+	}
+	if strings.TrimSpace(value) == "" {
+		b.err = append(b.err, fieldError(path, "required", value, "must not be empty"))
+		return b
+	}
+	set(value)
+	return b
+}
+
+// Currency takes an ISO 4217 code such as GBP.
+func (b *ChannelOrderBuilder) Currency(code string) *ChannelOrderBuilder {
+	if b == nil {
+		return nil
+	}
+	if !isUpperAlpha(code, 3) {
+		b.err = append(b.err, fieldError("$.Currency", "pattern", code, "must be a 3 letter ISO 4217 code"))
+		return b
+	}
+	b.data.Currency = code
+	return b
+}
+
+// ConversionRate is the Sale/Base currency rate.
+func (b *ChannelOrderBuilder) ConversionRate(rate float64) *ChannelOrderBuilder {
+	if b == nil {
+		return nil
+	}
+	if rate <= 0 {
+		b.err = append(b.err, fieldError("$.ConversionRate", "min", rate, "must be greater than 0"))
+		return b
+	}
+	b.data.ConversionRate = rate
+	return b
+}
+
+func (b *ChannelOrderBuilder) ReceivedDate(t time.Time) *ChannelOrderBuilder {
+	return b.date("$.ReceivedDate", t, func(v strfmt.DateTime) { b.data.ReceivedDate = v })
+}
+
+func (b *ChannelOrderBuilder) PaidOn(t time.Time) *ChannelOrderBuilder {
+	return b.date("$.PaidOn", t, func(v strfmt.DateTime) { b.data.PaidOn = v })
+}
+
+func (b *ChannelOrderBuilder) DispatchBy(t time.Time) *ChannelOrderBuilder {
+	return b.date("$.DispatchBy", t, func(v strfmt.DateTime) { b.data.DispatchBy = v })
+}
+
+func (b *ChannelOrderBuilder) date(path string, t time.Time, set func(strfmt.DateTime)) *ChannelOrderBuilder {
+	if b == nil {
+		return nil
+	}
+	if t.IsZero() {
+		b.err = append(b.err, fieldError(path, "required", nil, "must not be zero"))
+		return b
+	}
+	set(strfmt.DateTime(t.UTC()))
+	return b
+}
+
+// PaymentStatus is one of the models.ChannelOrderPaymentStatus* values. Unpaid orders are parked.
+func (b *ChannelOrderBuilder) PaymentStatus(status string) *ChannelOrderBuilder {
+	if b == nil {
+		return nil
+	}
+	b.data.PaymentStatus = status
+	return b
+}
+
+// PaymentMethod matches the payment method by name; SavePaymentMethodIfNotExist creates it when missing.
+func (b *ChannelOrderBuilder) PaymentMethod(name string, saveIfNotExist bool) *ChannelOrderBuilder {
+	if b == nil {
+		return nil
+	}
+	if name == "" {
+		b.err = append(b.err, fieldError("$.PaymentMethodName", "required", name, "must not be empty"))
+		return b
+	}
+	b.data.PaymentMethodName = name
+	b.data.MatchPaymentMethodTag = name
+	b.data.SavePaymentMethodIfNotExist = saveIfNotExist
+	return b
+}
+
+// PostalService sets the service name, its cost inclusive of tax after discount and the tax percent.
+func (b *ChannelOrderBuilder) PostalService(name string, cost, taxRate float64) *ChannelOrderBuilder {
+	if b == nil {
+		return nil
+	}
+	if name == "" {
+		b.err = append(b.err, fieldError("$.PostalServiceName", "required", name, "must not be empty"))
+	}
+	if cost < 0 {
+		b.err = append(b.err, fieldError("$.PostalServiceCost", "min", cost, "must not be negative"))
+	}
+	if taxRate < 0 || taxRate > 100 {
+		b.err = append(b.err, fieldError("$.PostalServiceTaxRate", "max", taxRate, "must be between 0 and 100"))
+	}
+	b.data.PostalServiceName = name
+	b.data.MatchPostalServiceTag = name
+	b.data.PostalServiceCost = cost
+	b.data.PostalServiceTaxRate = taxRate
+	return b
+}
+
+func (b *ChannelOrderBuilder) SavePostalServiceIfNotExist(value bool) *ChannelOrderBuilder {
+	if b == nil {
+		return nil
+	}
+	b.data.SavePostalServiceIfNotExist = value
+	return b
+}
+
+// Discount is an order level discount value applied after item discounts. discountType is one of
+// models.ChannelOrderDiscountType*, taxType one of models.ChannelOrderDiscountTaxType*.
+func (b *ChannelOrderBuilder) Discount(amount float64, discountType, taxType string) *ChannelOrderBuilder {
+	if b == nil {
+		return nil
+	}
+	if amount < 0 {
+		b.err = append(b.err, fieldError("$.Discount", "min", amount, "must not be negative"))
+		return b
+	}
+	b.data.Discount = amount
+	b.data.DiscountType = discountType
+	b.data.DiscountTaxType = taxType
+	return b
+}
+
+// OrderState saves the order as models.ChannelOrderOrderStateHold or Park.
+func (b *ChannelOrderBuilder) OrderState(state string) *ChannelOrderBuilder {
+	if b == nil {
+		return nil
+	}
+	b.data.OrderState = state
+	return b
+}
+
+func (b *ChannelOrderBuilder) UseChannelTax(value bool) *ChannelOrderBuilder {
+	if b == nil {
+		return nil
+	}
+	b.data.UseChannelTax = value
+	return b
+}
+
+// AutomaticallyLink links unmapped channel SKUs to stock items by SKU, barcode and ASIN.
+func (b *ChannelOrderBuilder) AutomaticallyLink(bySKU, byBarcode, byASIN bool) *ChannelOrderBuilder {
+	if b == nil {
+		return nil
+	}
+	b.data.AutomaticallyLinkBySKU = bySKU
+	b.data.AutomaticallyLinkByBarcode = byBarcode
+	b.data.AutomaticallyLinkByASIN = byASIN
+	return b
+}
+
+func (b *ChannelOrderBuilder) BillingAddress(address *ChannelAddressBuilder) *ChannelOrderBuilder {
+	if b == nil {
+		return nil
+	}
+	if address == nil {
+		b.err = append(b.err, fieldError("$.BillingAddress", "required", nil, "cannot be nil"))
+		return b
+	}
+	b.billing = address
+	return b
+}
+
+func (b *ChannelOrderBuilder) DeliveryAddress(address *ChannelAddressBuilder) *ChannelOrderBuilder {
+	if b == nil {
+		return nil
+	}
+	if address == nil {
+		b.err = append(b.err, fieldError("$.DeliveryAddress", "required", nil, "cannot be nil"))
+		return b
+	}
+	b.delivery = address
+	return b
+}
+
+// Items appends order lines; their errors are reported by Build.
+func (b *ChannelOrderBuilder) Items(items ...*ChannelOrderItemBuilder) *ChannelOrderBuilder {
+	if b == nil {
+		return nil
+	}
+	for _, item := range items {
+		if item == nil {
+			b.err = append(b.err, fieldError(fmt.Sprintf("$.OrderItems[%d]", len(b.items)), "required", nil, "cannot be nil"))
+			continue
+		}
+		b.items = append(b.items, item)
+	}
+	return b
+}
+
+func (b *ChannelOrderBuilder) Notes(notes ...*ChannelOrderNoteBuilder) *ChannelOrderBuilder {
+	if b == nil {
+		return nil
+	}
+	for _, note := range notes {
+		if note == nil {
+			b.err = append(b.err, fieldError(fmt.Sprintf("$.Notes[%d]", len(b.notes)), "required", nil, "cannot be nil"))
+			continue
+		}
+		b.notes = append(b.notes, note)
+	}
+	return b
+}
+
+// ExtendedProperty appends an order extended property; propType is free text such as "Info".
+func (b *ChannelOrderBuilder) ExtendedProperty(name, propType, value string) *ChannelOrderBuilder {
+	if b == nil {
+		return nil
+	}
+	if name == "" {
+		path := fmt.Sprintf("$.ExtendedProperties[%d].Name", len(b.data.ExtendedProperties))
+		b.err = append(b.err, fieldError(path, "required", name, "must not be empty"))
+		return b
+	}
+	b.data.ExtendedProperties = append(b.data.ExtendedProperties, &models.ChannelOrderExtendedProperty{
+		Name:  name,
+		Type:  propType,
+		Value: value,
+	})
+	return b
+}
+
+func (b *ChannelOrderBuilder) Build() (*models.ChannelOrder, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	order, errs := b.build()
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return order, nil
+}
+
+func (b *ChannelOrderBuilder) build() (*models.ChannelOrder, []error) {
+	errs := make([]error, len(b.err))
+	copy(errs, b.err)
+	order := *b.data
+
+	for _, f := range []struct{ path, value string }{
+		{"$.ReferenceNumber", order.ReferenceNumber},
+		{"$.Source", order.Source},
+		{"$.SubSource", order.SubSource},
+	} {
+		if f.value == "" {
+			errs = append(errs, fieldError(f.path, "required", nil, "is required"))
+		}
+	}
+	if b.billing != nil {
+		addr, aerrs := b.billing.build()
+		errs = append(errs, nest("$.BillingAddress", aerrs)...)
+		order.BillingAddress = addr
+	}
+	if b.delivery != nil {
+		addr, aerrs := b.delivery.build()
+		errs = append(errs, nest("$.DeliveryAddress", aerrs)...)
+		order.DeliveryAddress = addr
+	}
+	order.Notes = nil
+	for i, nb := range b.notes {
+		note, nerrs := nb.build()
+		errs = append(errs, nest(fmt.Sprintf("$.Notes[%d]", i), nerrs)...)
+		order.Notes = append(order.Notes, note)
+	}
+
+	if len(b.items) == 0 {
+		errs = append(errs, fieldError("$.OrderItems", "minItems", nil, "must contain at least one item"))
+	}
+	order.OrderItems = nil
+	lines := make(map[string]int, len(b.items))
+	var itemsTotal, itemsPostage float64
+	for i, ib := range b.items {
+		item, ierrs := ib.build()
+		path := fmt.Sprintf("$.OrderItems[%d]", i)
+		errs = append(errs, nest(path, ierrs)...)
+		order.OrderItems = append(order.OrderItems, item)
+		if item.ItemNumber != "" {
+			if first, dup := lines[item.ItemNumber]; dup {
+				errs = append(errs, fieldError(path+".ItemNumber", "uniqueItems", item.ItemNumber,
+					fmt.Sprintf("duplicates $.OrderItems[%d].ItemNumber", first)))
+			} else {
+				lines[item.ItemNumber] = i
+			}
+		}
+		itemsTotal += lineTotal(item)
+		itemsPostage += item.PostalServiceCost
+	}
+
+	if itemsPostage > 0 && order.PostalServiceCost > 0 && !sameAmount(itemsPostage, order.PostalServiceCost) {
+		errs = append(errs, fieldError("$.PostalServiceCost", "consistency", order.PostalServiceCost,
+			fmt.Sprintf("does not match the sum of item postal costs %.2f", itemsPostage)))
+	}
+	postage := math.Max(order.PostalServiceCost, itemsPostage)
+	if order.Discount > 0 {
+		if order.DiscountType == "" {
+			errs = append(errs, fieldError("$.DiscountType", "required", nil, "is required when Discount is set"))
+		}
+		// every DiscountType spreads the discount over items and postage
+		if limit := itemsTotal + postage; order.Discount > limit+amountTolerance {
+			errs = append(errs, fieldError("$.Discount", "consistency", order.Discount,
+				fmt.Sprintf("exceeds the discountable total %.2f", limit)))
+		}
+	}
+	return &order, errs
+}
+
+// ChannelOrderItemBuilder assembles one models.ChannelOrderItem.
+type ChannelOrderItemBuilder struct {
+	data  *models.ChannelOrderItem
+	taxes []*ChannelOrderItemTaxBuilder
+	err   []error
+}
+
+func NewChannelOrderItemBuilder() *ChannelOrderItemBuilder {
+	return &ChannelOrderItemBuilder{
+		data: &models.ChannelOrderItem{},
+		err:  make([]error, 0),
+	}
+}
+
+// ItemNumber is the unique line number within the order.
+func (b *ChannelOrderItemBuilder) ItemNumber(value string) *ChannelOrderItemBuilder {
+	return b.requiredString("$.ItemNumber", value, func(v string) { b.data.ItemNumber = v })
+}
+
+func (b *ChannelOrderItemBuilder) ChannelSKU(value string) *ChannelOrderItemBuilder {
+	return b.requiredString("$.ChannelSKU", value, func(v string) { b.data.ChannelSKU = v })
+}
+
+func (b *ChannelOrderItemBuilder) ChannelReferenceID(value string) *ChannelOrderItemBuilder {
+	return b.requiredString("$.ChannelReferenceId", value, func(v string) { b.data.ChannelReferenceID = v })
+}
+
+func (b *ChannelOrderItemBuilder) ItemTitle(value string) *ChannelOrderItemBuilder {
+	return b.requiredString("$.ItemTitle", value, func(v string) { b.data.ItemTitle = v })
+}
+
+func (b *ChannelOrderItemBuilder) requiredString(path, value string, set func(string)) *ChannelOrderItemBuilder {
+	if b == nil {
+		return nil
+	}
+	if strings.TrimSpace(value) == "" {
+		b.err = append(b.err, fieldError(path, "required", value, "must not be empty"))
+		return b
+	}
+	set(value)
+	return b
+}
+
+func (b *ChannelOrderItemBuilder) Qty(qty int32) *ChannelOrderItemBuilder {
+	if b == nil {
+		return nil
+	}
+	if qty <= 0 {
+		b.err = append(b.err, fieldError("$.Qty", "min", qty, "must be greater than 0"))
+		return b
+	}
+	b.data.Qty = qty
+	return b
+}
+
+// PricePerUnit is the unit price; see TaxCostInclusive for whether it includes tax.
+func (b *ChannelOrderItemBuilder) PricePerUnit(price float64) *ChannelOrderItemBuilder {
+	if b == nil {
+		return nil
+	}
+	if price < 0 {
+		b.err = append(b.err, fieldError("$.PricePerUnit", "min", price, "must not be negative"))
+		return b
+	}
+	b.data.PricePerUnit = price
+	return b
+}
+
+// LineDiscount is a percentage of the line as a whole number, e.g. 10.
+func (b *ChannelOrderItemBuilder) LineDiscount(percent float64) *ChannelOrderItemBuilder {
+	return b.percent("$.LineDiscount", percent, func(v float64) { b.data.LineDiscount = v })
+}
+
+// TaxRate is a percentage as a whole number, e.g. 20.
+func (b *ChannelOrderItemBuilder) TaxRate(percent float64) *ChannelOrderItemBuilder {
+	return b.percent("$.TaxRate", percent, func(v float64) { b.data.TaxRate = v })
+}
+
+func (b *ChannelOrderItemBuilder) percent(path string, value float64, set func(float64)) *ChannelOrderItemBuilder {
+	if b == nil {
+		return nil
+	}
+	if value < 0 || value > 100 {
+		b.err = append(b.err, fieldError(path, "max", value, "must be between 0 and 100"))
+		return b
+	}
+	set(value)
+	return b
+}
+
+func (b *ChannelOrderItemBuilder) TaxCostInclusive(value bool) *ChannelOrderItemBuilder {
+	if b == nil {
+		return nil
+	}
+	b.data.TaxCostInclusive = value
+	return b
+}
+
+func (b *ChannelOrderItemBuilder) UseChannelTax(value bool) *ChannelOrderItemBuilder {
+	if b == nil {
+		return nil
+	}
+	b.data.UseChannelTax = value
+	return b
+}
+
+// IsService marks the line as a service rather than a physical item; services need no ChannelSKU.
+func (b *ChannelOrderItemBuilder) IsService(value bool) *ChannelOrderItemBuilder {
+	if b == nil {
+		return nil
+	}
+	b.data.IsService = value
+	return b
+}
+
+// PostalServiceCost is the line share of shipping after discount.
+func (b *ChannelOrderItemBuilder) PostalServiceCost(cost float64) *ChannelOrderItemBuilder {
+	if b == nil {
+		return nil
+	}
+	if cost < 0 {
+		b.err = append(b.err, fieldError("$.PostalServiceCost", "min", cost, "must not be negative"))
+		return b
+	}
+	b.data.PostalServiceCost = cost
+	return b
+}
+
+// LineRefund is the amount refunded on the line excluding shipping.
+func (b *ChannelOrderItemBuilder) LineRefund(amount float64) *ChannelOrderItemBuilder {
+	if b == nil {
+		return nil
+	}
+	if amount < 0 {
+		b.err = append(b.err, fieldError("$.LineRefund", "min", amount, "must not be negative"))
+		return b
+	}
+	b.data.LineRefund = amount
+	return b
+}
+
+// Option appends an item option such as Size=XL.
+func (b *ChannelOrderItemBuilder) Option(property, value string) *ChannelOrderItemBuilder {
+	if b == nil {
+		return nil
+	}
+	if property == "" {
+		path := fmt.Sprintf("$.Options[%d].Property", len(b.data.Options))
+		b.err = append(b.err, fieldError(path, "required", property, "must not be empty"))
+		return b
+	}
+	b.data.Options = append(b.data.Options, &models.ChannelOrderItemOption{Property: property, Value: value})
+	return b
+}
+
+func (b *ChannelOrderItemBuilder) Taxes(taxes ...*ChannelOrderItemTaxBuilder) *ChannelOrderItemBuilder {
+	if b == nil {
+		return nil
+	}
+	for _, tax := range taxes {
+		if tax == nil {
+			b.err = append(b.err, fieldError(fmt.Sprintf("$.Taxes[%d]", len(b.taxes)), "required", nil, "cannot be nil"))
+			continue
+		}
+		b.taxes = append(b.taxes, tax)
+	}
+	return b
+}
+
+func (b *ChannelOrderItemBuilder) Build() (*models.ChannelOrderItem, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	item, errs := b.build()
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return item, nil
+}
+
+func (b *ChannelOrderItemBuilder) build() (*models.ChannelOrderItem, []error) {
+	errs := make([]error, len(b.err))
+	copy(errs, b.err)
+	item := *b.data
+
+	if item.ItemNumber == "" {
+		errs = append(errs, fieldError("$.ItemNumber", "required", nil, "is required"))
+	}
+	if item.Qty == 0 {
+		errs = append(errs, fieldError("$.Qty", "required", nil, "is required"))
+	}
+	if item.IsService {
+		if item.ItemTitle == "" {
+			errs = append(errs, fieldError("$.ItemTitle", "required", nil, "is required for services"))
+		}
+	} else if item.ChannelSKU == "" {
+		errs = append(errs, fieldError("$.ChannelSKU", "required", nil, "is required"))
+	}
+	if total := lineTotal(&item); item.LineRefund > total+amountTolerance {
+		errs = append(errs, fieldError("$.LineRefund", "consistency", item.LineRefund,
+			fmt.Sprintf("exceeds the line total %.2f", total)))
+	}
+
+	item.Taxes = nil
+	for i, tb := range b.taxes {
+		tax, terrs := tb.build()
+		errs = append(errs, nest(fmt.Sprintf("$.Taxes[%d]", i), terrs)...)
+		item.Taxes = append(item.Taxes, tax)
+	}
+	return &item, errs
+}
+
+// ChannelOrderItemTaxBuilder assembles a channel collected tax line of an item.
+type ChannelOrderItemTaxBuilder struct {
+	data *models.ChannelOrderItemTax
+	err  []error
+}
+
+func NewChannelOrderItemTaxBuilder() *ChannelOrderItemTaxBuilder {
+	return &ChannelOrderItemTaxBuilder{
+		data: &models.ChannelOrderItemTax{},
+		err:  make([]error, 0),
+	}
+}
+
+func (b *ChannelOrderItemTaxBuilder) TaxType(value string) *ChannelOrderItemTaxBuilder {
+	if b == nil {
+		return nil
+	}
+	if value == "" {
+		b.err = append(b.err, fieldError("$.TaxType", "required", value, "must not be empty"))
+		return b
+	}
+	b.data.TaxType = value
+	return b
+}
+
+func (b *ChannelOrderItemTaxBuilder) TaxValue(value float64) *ChannelOrderItemTaxBuilder {
+	if b == nil {
+		return nil
+	}
+	if value < 0 {
+		b.err = append(b.err, fieldError("$.TaxValue", "min", value, "must not be negative"))
+		return b
+	}
+	b.data.TaxValue = value
+	return b
+}
+
+// SellerCollected marks tax collected by the seller rather than the marketplace.
+func (b *ChannelOrderItemTaxBuilder) SellerCollected(value bool) *ChannelOrderItemTaxBuilder {
+	if b == nil {
+		return nil
+	}
+	b.data.IsSellerCollected = value
+	return b
+}
+
+func (b *ChannelOrderItemTaxBuilder) build() (*models.ChannelOrderItemTax, []error) {
+	errs := make([]error, len(b.err))
+	copy(errs, b.err)
+	if b.data.TaxType == "" {
+		errs = append(errs, fieldError("$.TaxType", "required", nil, "is required"))
+	}
+	tax := *b.data
+	return &tax, errs
+}
+
+// ChannelAddressBuilder assembles a billing or delivery models.ChannelAddress.
+type ChannelAddressBuilder struct {
+	data *models.ChannelAddress
+	err  []error
+}
+
+func NewChannelAddressBuilder() *ChannelAddressBuilder {
+	return &ChannelAddressBuilder{
+		data: &models.ChannelAddress{},
+		err:  make([]error, 0),
+	}
+}
+
+func (b *ChannelAddressBuilder) FullName(value string) *ChannelAddressBuilder {
+	return b.set(value, func(v string) { b.data.FullName = v })
+}
+
+func (b *ChannelAddressBuilder) Company(value string) *ChannelAddressBuilder {
+	return b.set(value, func(v string) { b.data.Company = v })
+}
+
+// Address sets up to three address lines.
+func (b *ChannelAddressBuilder) Address(lines ...string) *ChannelAddressBuilder {
+	if b == nil {
+		return nil
+	}
+	if len(lines) == 0 || len(lines) > 3 {
+		b.err = append(b.err, fieldError("$.Address1", "maxItems", len(lines), "address takes 1 to 3 lines"))
+		return b
+	}
+	dst := []*string{&b.data.Address1, &b.data.Address2, &b.data.Address3}
+	for i, line := range lines {
+		*dst[i] = line
+	}
+	return b
+}
+
+func (b *ChannelAddressBuilder) Town(value string) *ChannelAddressBuilder {
+	return b.set(value, func(v string) { b.data.Town = v })
+}
+
+func (b *ChannelAddressBuilder) Region(value string) *ChannelAddressBuilder {
+	return b.set(value, func(v string) { b.data.Region = v })
+}
+
+func (b *ChannelAddressBuilder) PostCode(value string) *ChannelAddressBuilder {
+	return b.set(value, func(v string) { b.data.PostCode = v })
+}
+
+// Country sets the country name as received from the channel.
+func (b *ChannelAddressBuilder) Country(value string) *ChannelAddressBuilder {
+	return b.set(value, func(v string) { b.data.Country = v })
+}
+
+// CountryCode matches the Linnworks country by ISO 3166-1 alpha-2 code, e.g. GB.
+func (b *ChannelAddressBuilder) CountryCode(code string) *ChannelAddressBuilder {
+	if b == nil {
+		return nil
+	}
+	if !isUpperAlpha(code, 2) {
+		b.err = append(b.err, fieldError("$.MatchCountryCode", "pattern", code, "must be a 2 letter ISO 3166-1 code"))
+		return b
+	}
+	b.data.MatchCountryCode = code
+	return b
+}
+
+func (b *ChannelAddressBuilder) EmailAddress(value string) *ChannelAddressBuilder {
+	if b == nil {
+		return nil
+	}
+	if !strfmt.IsEmail(value) {
+		b.err = append(b.err, fieldError("$.EmailAddress", "type", value, "must be an email address"))
+		return b
+	}
+	b.data.EmailAddress = value
+	return b
+}
+
+func (b *ChannelAddressBuilder) PhoneNumber(value string) *ChannelAddressBuilder {
+	return b.set(value, func(v string) { b.data.PhoneNumber = v })
+}
+
+func (b *ChannelAddressBuilder) set(value string, set func(string)) *ChannelAddressBuilder {
+	if b == nil {
+		return nil
+	}
+	set(value)
+	return b
+}
+
+func (b *ChannelAddressBuilder) build() (*models.ChannelAddress, []error) {
+	errs := make([]error, len(b.err))
+	copy(errs, b.err)
+	if b.data.FullName == "" && b.data.Company == "" {
+		errs = append(errs, fieldError("$.FullName", "required", nil, "FullName or Company is required"))
+	}
+	if b.data.Address1 == "" {
+		errs = append(errs, fieldError("$.Address1", "required", nil, "is required"))
+	}
+	if b.data.Country == "" && b.data.MatchCountryCode == "" {
+		errs = append(errs, fieldError("$.Country", "required", nil, "Country or CountryCode is required"))
+	}
+	addr := *b.data
+	return &addr, errs
+}
+
+// ChannelOrderNoteBuilder assembles an order note.
+type ChannelOrderNoteBuilder struct {
+	data *models.ChannelOrderNote
+	err  []error
+}
+
+func NewChannelOrderNoteBuilder() *ChannelOrderNoteBuilder {
+	return &ChannelOrderNoteBuilder{
+		data: &models.ChannelOrderNote{},
+		err:  make([]error, 0),
+	}
+}
+
+func (b *ChannelOrderNoteBuilder) Note(text string) *ChannelOrderNoteBuilder {
+	if b == nil {
+		return nil
+	}
+	if strings.TrimSpace(text) == "" {
+		b.err = append(b.err, fieldError("$.Note", "required", text, "must not be empty"))
+		return b
+	}
+	b.data.Note = text
+	return b
+}
+
+// Internal hides the note from the customer.
+func (b *ChannelOrderNoteBuilder) Internal(value bool) *ChannelOrderNoteBuilder {
+	if b == nil {
+		return nil
+	}
+	b.data.Internal = value
+	return b
+}
+
+func (b *ChannelOrderNoteBuilder) EntryDate(t time.Time) *ChannelOrderNoteBuilder {
+	if b == nil {
+		return nil
+	}
+	b.data.NoteEntryDate = strfmt.DateTime(t.UTC())
+	return b
+}
+
+func (b *ChannelOrderNoteBuilder) UserName(value string) *ChannelOrderNoteBuilder {
+	if b == nil {
+		return nil
+	}
+	b.data.NoteUserName = value
+	return b
+}
+
+func (b *ChannelOrderNoteBuilder) build() (*models.ChannelOrderNote, []error) {
+	errs := make([]error, len(b.err))
+	copy(errs, b.err)
+	if b.data.Note == "" {
+		errs = append(errs, fieldError("$.Note", "required", nil, "is required"))
+	}
+	note := *b.data
+	return &note, errs
+}
+
+// amountTolerance absorbs rounding of money values to pennies.
+const amountTolerance = 0.005
+
+func sameAmount(a, b float64) bool { return math.Abs(a-b) <= amountTolerance }
+
+// lineTotal is the line value after the line discount.
+func lineTotal(item *models.ChannelOrderItem) float64 {
+	return float64(item.Qty) * item.PricePerUnit * (1 - item.LineDiscount/100)
+}
+
+func fieldError(path, rule string, value any, message string) *lw_api.FieldError {
+	return &lw_api.FieldError{Path: path, Rule: rule, Value: value, Message: message}
+}
+
+// nest re-roots sub-builder errors under path, e.g. $.OrderItems[2]. Errors are copied, so
+// building twice does not nest twice.
+func nest(path string, errs []error) []error {
+	out := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if fe, ok := err.(*lw_api.FieldError); ok {
+			c := *fe
+			c.Path = path + strings.TrimPrefix(fe.Path, "$")
+			out = append(out, &c)
+			continue
+		}
+		out = append(out, fmt.Errorf("%s: %w", path, err))
+	}
+	return out
+}
+
+func isUpperAlpha(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
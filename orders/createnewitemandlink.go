@@ -0,0 +1,50 @@
+package orders
+
+import (
+	"errors"
+
+	lw_api "github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+func (b *CreateNewItemAndLinkRequestBuilder) Source(source, subSource string) *CreateNewItemAndLinkRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	if source == "" || subSource == "" {
+		b.err = append(b.err, errors.New("source and subSource cannot be empty"))
+		return b
+	}
+	b.data.Source = source
+	b.data.SubSource = subSource
+	return b
+}
+
+// build also needs LocationID with InitialQuantity: that is where the stock is booked in.
+func (b *CreateNewItemAndLinkRequestBuilder) build() (*models.OrdersCreateNewItemAndLinkRequest, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	errs := make([]error, len(b.err))
+	copy(errs, b.err)
+	if b.data.ChannelSKU == "" {
+		errs = append(errs, errors.New("channelSKU is required"))
+	}
+	if b.data.ItemTitle == "" {
+		errs = append(errs, errors.New("itemTitle is required"))
+	}
+	if b.data.Source == "" {
+		errs = append(errs, errors.New("source is required"))
+	}
+	if b.data.InitialQuantity < 0 {
+		errs = append(errs, errors.New("initialQuantity must not be negative"))
+	}
+	if b.data.InitialQuantity > 0 && b.data.LocationID == "" {
+		errs = append(errs, errors.New("locationId is required with initialQuantity"))
+	}
+	errs = append(errs, lw_api.ValidatePayload(b.ctx, b.data)...)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return b.data, nil
+}
@@ -0,0 +1,11 @@
+package orders
+
+import "errors"
+
+func (b *CreateNewOrderRequestBuilder) requiredField() []error {
+	var errs []error
+	if b.data.FulfilmentCenter == "" {
+		errs = append(errs, errors.New("fulfilmentCenter is required"))
+	}
+	return errs
+}
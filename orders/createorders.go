@@ -0,0 +1,61 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+
+	lw_api "github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+// Orders builds and appends channel orders. Their errors are reported by Do as
+// *lw_api.FieldError under $.orders[i].
+func (b *CreateOrdersRequestBuilder) Orders(orders ...*ChannelOrderBuilder) *CreateOrdersRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	for _, ob := range orders {
+		path := fmt.Sprintf("$.orders[%d]", len(b.data.Orders))
+		if ob == nil {
+			b.err = append(b.err, fieldError(path, "required", nil, "cannot be nil"))
+			continue
+		}
+		order, errs := ob.build()
+		b.err = append(b.err, nest(path, errs)...)
+		b.data.Orders = append(b.data.Orders, order)
+	}
+	return b
+}
+
+// build rejects orders that repeat a reference number from the same Source and SubSource:
+// Linnworks would update the first one instead of creating the second.
+func (b *CreateOrdersRequestBuilder) build() (*models.OrdersCreateOrdersRequest, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	errs := make([]error, len(b.err))
+	copy(errs, b.err)
+	if len(b.data.Orders) == 0 {
+		errs = append(errs, errors.New("orders must contain at least one value"))
+	}
+
+	type channelRef struct{ source, subSource, reference string }
+	seen := make(map[channelRef]int, len(b.data.Orders))
+	for i, order := range b.data.Orders {
+		if order.ReferenceNumber == "" {
+			continue
+		}
+		ref := channelRef{order.Source, order.SubSource, order.ReferenceNumber}
+		if first, dup := seen[ref]; dup {
+			errs = append(errs, fieldError(fmt.Sprintf("$.orders[%d].ReferenceNumber", i), "uniqueItems", order.ReferenceNumber,
+				fmt.Sprintf("duplicates $.orders[%d] from the same Source and SubSource", first)))
+			continue
+		}
+		seen[ref] = i
+	}
+	errs = append(errs, lw_api.ValidatePayload(b.ctx, b.data)...)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return b.data, nil
+}
@@ -0,0 +1,130 @@
+package orders_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/lwmock"
+	"github.com/MMC-BK/lw-api/orders"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+func channelOrder(ref string) *orders.ChannelOrderBuilder {
+	return orders.NewChannelOrderBuilder().
+		ReferenceNumber(ref).
+		Source("MYSHOP").
+		SubSource("myshop.example").
+		Currency("GBP").
+		PostalService("Standard", 4.99, 20).
+		DeliveryAddress(orders.NewChannelAddressBuilder().
+			FullName("Jane Doe").
+			Address("1 High Street").
+			Town("London").
+			PostCode("N1 1AA").
+			CountryCode("GB")).
+		Items(orders.NewChannelOrderItemBuilder().
+			ItemNumber("1").
+			ChannelSKU("SKU-1").
+			ItemTitle("Mug").
+			Qty(2).
+			PricePerUnit(10).
+			TaxRate(20))
+}
+
+func paths(err error) []string {
+	var out []string
+	for _, fe := range client.FieldErrors(err) {
+		out = append(out, fe.Path)
+	}
+	slices.Sort(out)
+	return out
+}
+
+func TestCreateOrders(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should send built channel orders", func(t *testing.T) {
+		m := lwmock.New()
+		m.OnCreateOrders(func(req *models.OrdersCreateOrdersRequest) ([]strfmt.UUID, error) {
+			return []strfmt.UUID{"0b8f7d2e-4b1a-4c55-9d0e-3c2a1f6b7e11"}, nil
+		})
+		ids, err := m.CreateOrders(ctx).
+			Location("Default").
+			Orders(channelOrder("A-1").
+				Notes(orders.NewChannelOrderNoteBuilder().Note("gift wrap")).
+				ExtendedProperty("Channel", "Info", "web")).
+			Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if len(ids) != 1 {
+			t.Errorf("Expected 1 id, got %v", ids)
+		}
+		var sent models.OrdersCreateOrdersRequest
+		if err := m.LastCall("/api/Orders/CreateOrders").Decode(&sent); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		o := sent.Orders[0]
+		if o.ReferenceNumber != "A-1" || o.DeliveryAddress.MatchCountryCode != "GB" || o.OrderItems[0].Qty != 2 || o.Notes[0].Note != "gift wrap" {
+			t.Errorf("unexpected order %+v", o)
+		}
+	})
+
+	t.Run("should report missing identity and item fields with paths", func(t *testing.T) {
+		_, err := lwmock.New().CreateOrders(ctx).
+			Orders(orders.NewChannelOrderBuilder().
+				Items(orders.NewChannelOrderItemBuilder().ItemNumber("1").Qty(1))).
+			Do()
+		want := []string{"$.orders[0].OrderItems[0].ChannelSKU", "$.orders[0].ReferenceNumber", "$.orders[0].Source", "$.orders[0].SubSource"}
+		if got := paths(err); !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("should check totals, discounts and postal cost", func(t *testing.T) {
+		order := channelOrder("A-2").
+			Discount(100, models.ChannelOrderDiscountTypeAllEvenly, models.ChannelOrderDiscountTaxTypeDeductAfterTax).
+			Items(orders.NewChannelOrderItemBuilder().
+				ItemNumber("1").
+				ChannelSKU("SKU-2").
+				Qty(1).
+				PricePerUnit(5).
+				PostalServiceCost(1).
+				LineRefund(6))
+		_, err := lwmock.New().CreateOrders(ctx).Orders(order).Do()
+		want := []string{
+			"$.orders[0].Discount",
+			"$.orders[0].OrderItems[1].ItemNumber",
+			"$.orders[0].OrderItems[1].LineRefund",
+			"$.orders[0].PostalServiceCost",
+		}
+		if got := paths(err); !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("should reject duplicate orders and spec violations", func(t *testing.T) {
+		_, err := lwmock.New().CreateOrders(ctx).
+			Orders(channelOrder("A-3"), channelOrder("A-3").PaymentStatus("Maybe")).
+			Do()
+		want := []string{"$.orders[1].PaymentStatus", "$.orders[1].ReferenceNumber"}
+		if got := paths(err); !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("should require a fulfilment center for a new order", func(t *testing.T) {
+		m := lwmock.New()
+		if _, err := m.CreateNewOrder(ctx).CreateAsDraft(true).Do(); err == nil {
+			t.Error("Expected error, got nil")
+		}
+		if _, err := m.CreateNewOrder(ctx).FulfilmentCenter("0b8f7d2e-4b1a-4c55-9d0e-3c2a1f6b7e11").Do(); !errors.Is(err, lwmock.ErrNotStubbed) {
+			t.Errorf("Expected ErrNotStubbed, got %v", err)
+		}
+	})
+}
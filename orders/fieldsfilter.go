@@ -0,0 +1,313 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+// FieldsFilterBuilder assembles models.FieldsFilter for open order searches.
+// Field codes are the models.*FieldCode* enum values, e.g. models.TextFieldFilterFieldCodeGENERALINFOREFERENCENUMBER.
+type FieldsFilterBuilder struct {
+	data *models.FieldsFilter
+	err  []error
+}
+
+func NewFieldsFilterBuilder() *FieldsFilterBuilder {
+	return &FieldsFilterBuilder{
+		data: &models.FieldsFilter{},
+		err:  make([]error, 0),
+	}
+}
+
+func (b *FieldsFilterBuilder) TextEqual(fieldCode, text string) *FieldsFilterBuilder {
+	return b.text(models.TextFieldFilterTypeEqual, fieldCode, text)
+}
+
+func (b *FieldsFilterBuilder) TextContains(fieldCode, text string) *FieldsFilterBuilder {
+	return b.text(models.TextFieldFilterTypeContains, fieldCode, text)
+}
+
+func (b *FieldsFilterBuilder) TextStartsWith(fieldCode, text string) *FieldsFilterBuilder {
+	return b.text(models.TextFieldFilterTypeStartWith, fieldCode, text)
+}
+
+func (b *FieldsFilterBuilder) TextEndsWith(fieldCode, text string) *FieldsFilterBuilder {
+	return b.text(models.TextFieldFilterTypeEndsWith, fieldCode, text)
+}
+
+func (b *FieldsFilterBuilder) text(filterType, fieldCode, text string) *FieldsFilterBuilder {
+	if b == nil {
+		return nil
+	}
+	if text == "" {
+		b.err = append(b.err, fmt.Errorf("text filter %s: text is required", fieldCode))
+		return b
+	}
+	filter := &models.TextFieldFilter{FieldCode: fieldCode, Type: filterType, Text: text}
+	if b.validate("text", fieldCode, filter) {
+		b.data.TextFields = append(b.data.TextFields, filter)
+	}
+	return b
+}
+
+func (b *FieldsFilterBuilder) NumericEqual(fieldCode string, value float64) *FieldsFilterBuilder {
+	return b.numeric(models.NumericFieldFilterTypeEqual, fieldCode, value)
+}
+
+func (b *FieldsFilterBuilder) NumericGreater(fieldCode string, value float64) *FieldsFilterBuilder {
+	return b.numeric(models.NumericFieldFilterTypeGreater, fieldCode, value)
+}
+
+func (b *FieldsFilterBuilder) NumericLower(fieldCode string, value float64) *FieldsFilterBuilder {
+	return b.numeric(models.NumericFieldFilterTypeLower, fieldCode, value)
+}
+
+func (b *FieldsFilterBuilder) numeric(filterType, fieldCode string, value float64) *FieldsFilterBuilder {
+	if b == nil {
+		return nil
+	}
+	filter := &models.NumericFieldFilter{FieldCode: fieldCode, Type: filterType, Value: value}
+	if b.validate("numeric", fieldCode, filter) {
+		b.data.NumericFields = append(b.data.NumericFields, filter)
+	}
+	return b
+}
+
+func (b *FieldsFilterBuilder) DateRange(fieldCode string, from, to time.Time) *FieldsFilterBuilder {
+	if b == nil {
+		return nil
+	}
+	if from.IsZero() || to.IsZero() {
+		b.err = append(b.err, fmt.Errorf("date filter %s: range requires both from and to", fieldCode))
+		return b
+	}
+	if to.Before(from) {
+		b.err = append(b.err, fmt.Errorf("date filter %s: to must not be before from", fieldCode))
+		return b
+	}
+	filter := &models.DateFieldFilter{
+		FieldCode: fieldCode,
+		Type:      models.DateFieldFilterTypeRange,
+		DateFrom:  strfmt.DateTime(from),
+		DateTo:    strfmt.DateTime(to),
+	}
+	if b.validate("date", fieldCode, filter) {
+		b.data.DateFields = append(b.data.DateFields, filter)
+	}
+	return b
+}
+
+func (b *FieldsFilterBuilder) DateOlderThan(fieldCode string, days int32) *FieldsFilterBuilder {
+	return b.dateDays(models.DateFieldFilterTypeOlderThan, fieldCode, days)
+}
+
+func (b *FieldsFilterBuilder) DateLastDays(fieldCode string, days int32) *FieldsFilterBuilder {
+	return b.dateDays(models.DateFieldFilterTypeLastDays, fieldCode, days)
+}
+
+func (b *FieldsFilterBuilder) dateDays(filterType, fieldCode string, days int32) *FieldsFilterBuilder {
+	if b == nil {
+		return nil
+	}
+	if days <= 0 {
+		b.err = append(b.err, fmt.Errorf("date filter %s: days must be greater than 0", fieldCode))
+		return b
+	}
+	filter := &models.DateFieldFilter{FieldCode: fieldCode, Type: filterType, Value: days}
+	if b.validate("date", fieldCode, filter) {
+		b.data.DateFields = append(b.data.DateFields, filter)
+	}
+	return b
+}
+
+func (b *FieldsFilterBuilder) ListIs(fieldCode, value string) *FieldsFilterBuilder {
+	return b.list(models.ListFieldFilterTypeIs, fieldCode, value)
+}
+
+func (b *FieldsFilterBuilder) ListNot(fieldCode, value string) *FieldsFilterBuilder {
+	return b.list(models.ListFieldFilterTypeNot, fieldCode, value)
+}
+
+func (b *FieldsFilterBuilder) list(filterType, fieldCode, value string) *FieldsFilterBuilder {
+	if b == nil {
+		return nil
+	}
+	if value == "" {
+		b.err = append(b.err, fmt.Errorf("list filter %s: value is required", fieldCode))
+		return b
+	}
+	filter := &models.ListFieldFilter{FieldCode: fieldCode, Type: filterType, Value: value}
+	if b.validate("list", fieldCode, filter) {
+		b.data.ListFields = append(b.data.ListFields, filter)
+	}
+	return b
+}
+
+func (b *FieldsFilterBuilder) Boolean(fieldCode string, value bool) *FieldsFilterBuilder {
+	if b == nil {
+		return nil
+	}
+	filter := &models.BooleanFieldFilter{FieldCode: fieldCode, Value: value}
+	if b.validate("boolean", fieldCode, filter) {
+		b.data.BooleanFields = append(b.data.BooleanFields, filter)
+	}
+	return b
+}
+
+func (b *FieldsFilterBuilder) validate(kind, fieldCode string, filter interface {
+	Validate(strfmt.Registry) error
+}) bool {
+	if fieldCode == "" {
+		b.err = append(b.err, fmt.Errorf("%s filter: field code is required", kind))
+		return false
+	}
+	if err := filter.Validate(strfmt.NewFormats()); err != nil {
+		b.err = append(b.err, fmt.Errorf("%s filter %s: %w", kind, fieldCode, err))
+		return false
+	}
+	switch want, ok := filterKinds[fieldCode]; {
+	case !ok:
+		b.err = append(b.err, fmt.Errorf("%s filter %s: field cannot be filtered", kind, fieldCode))
+		return false
+	case want != kind:
+		b.err = append(b.err, fmt.Errorf("%s filter %s: field takes a %s filter", kind, fieldCode, want))
+		return false
+	}
+	return true
+}
+
+// filterKinds maps the filterable field codes to the filter they take. Every filter model
+// accepts the same field code enum, but Linnworks rejects a filter of the wrong kind. Group
+// codes such as GENERAL_INFO and ITEMS are left out: they cannot be filtered.
+var filterKinds = map[string]string{
+	"GENERAL_INFO_DATE":                 "date",
+	"GENERAL_INFO_DESPATCHBYDATE":       "date",
+	"SHIPPING_SCHEDULED_DELIVERY_START": "date",
+	"SHIPPING_SCHEDULED_DELIVERY_END":   "date",
+
+	"GENERAL_INFO_ORDER_ID":       "numeric",
+	"GENERAL_INFO_ITEMS_COUNT":    "numeric",
+	"GENERAL_INFO_NOTE_COUNT":     "numeric",
+	"SHIPPING_INFORMATION_WEIGHT": "numeric",
+	"SHIPPING_INFORMATION_COST":   "numeric",
+	"TOTALS_SUBTOTAL":             "numeric",
+	"TOTALS_SHIPPING":             "numeric",
+	"TOTALS_TAX":                  "numeric",
+	"TOTALS_TOTAL":                "numeric",
+	"TOTALS_DISCOUNT":             "numeric",
+	"TOTALS_COUNTRY_TAX_RATE":     "numeric",
+	"ORDER_TOTAL_TOTAL":           "numeric",
+	"ORDER_TAX_TOTAL":             "numeric",
+	"ITEMS_QUANTITY":              "numeric",
+	"ITEMS_LINE":                  "numeric",
+	"ITEMS_COST_INC_TAX":          "numeric",
+	"ITEMS_COST":                  "numeric",
+	"ITEMS_SALES_TAX":             "numeric",
+	"ITEMS_TAX_RATE":              "numeric",
+	"ITEMS_DISCOUNT":              "numeric",
+	"ITEMS_SUM_QUANTITY":          "numeric",
+	"ITEMS_WEIGHT":                "numeric",
+	"ITEMS_UNIT_COST":             "numeric",
+	"ITEMS_PRICE_PER_UNIT":        "numeric",
+	"STOCK_LEVEL":                 "numeric",
+
+	"GENERAL_INFO_LABEL_PRINTED":            "boolean",
+	"GENERAL_INFO_INVOICE_PRINTED":          "boolean",
+	"GENERAL_INFO_PICK_LIST_PRINTED":        "boolean",
+	"GENERAL_INFO_IS_RULE_RUN":              "boolean",
+	"GENERAL_INFO_LOCKED":                   "boolean",
+	"GENERAL_INFO_PARKED":                   "boolean",
+	"GENERAL_INFO_PART_SHIPPED":             "boolean",
+	"GENERAL_INFO_HAS_SHIPPING_LABEL_ERROR": "boolean",
+	"ITEMS_IS_SERVICE":                      "boolean",
+	"ITEMS_TAX_COST_INCLUSIVE":              "boolean",
+	"ITEMS_BATCHED":                         "boolean",
+	"CAN_FULFIL":                            "boolean",
+
+	"GENERAL_INFO_SOURCE":                  "list",
+	"GENERAL_INFO_SUBSOURCE":               "list",
+	"GENERAL_INFO_TAG":                     "list",
+	"GENERAL_INFO_STATUS":                  "list",
+	"GENERAL_INFO_IDENTIFIER":              "list",
+	"GENERAL_INFO_STOCK_ALLOCATION":        "list",
+	"SHIPPING_INFORMATION_VENDOR":          "list",
+	"SHIPPING_INFORMATION_SERVICE":         "list",
+	"SHIPPING_INFORMATION_SERVICE_ID":      "list",
+	"SHIPPING_INFORMATION_PACKAGING_GROUP": "list",
+	"SHIPPING_INFORMATION_PACKAGING_TYPE":  "list",
+	"CUSTOMER_ADDRESS_COUNTRY":             "list",
+	"CUSTOMER_ADDRESS_COUNTRY_ZONE":        "list",
+	"CUSTOMER_BILLING_ADDRESS_COUNTRY":     "list",
+	"TOTALS_CURRENCY":                      "list",
+	"TOTALS_PAYMENT_METHOD":                "list",
+	"ORDER_TOTAL_CURRENCY":                 "list",
+	"ORDER_TAX_CURRENCY":                   "list",
+	"ITEMS_CURRENCY":                       "list",
+	"ITEMS_CATEGORY":                       "list",
+	"ITEMS_INVENTORY_TRACKING_TYPE":        "list",
+	"FOLDER":                               "list",
+	"FOLDERS":                              "list",
+	"LOCATION_ID":                          "list",
+	"FULFILLMENT_STATE":                    "list",
+
+	"GENERAL_INFO_REFERENCE_NUMBER":          "text",
+	"GENERAL_INFO_CHANNEL_REFERENCE_NUMBER":  "text",
+	"GENERAL_INFO_EXTERNAL_REFERENCE_NUMBER": "text",
+	"GENERAL_INFO_NOTES":                     "text",
+	"GENERAL_INFO_NOTE":                      "text",
+	"GENERAL_INFO_SHIPPING_LABEL_ERROR":      "text",
+	"GENERAL_INFO_INVOICE_PRINT_ERROR":       "text",
+	"GENERAL_INFO_PICK_LIST_PRINT_ERROR":     "text",
+	"GENERAL_INFO_PICKWAVE_IDS":              "text",
+	"SHIPPING_INFORMATION_TRACKING_NUMBER":   "text",
+	"CUSTOMER_ADDRESS_ADDRESS":               "text",
+	"CUSTOMER_ADDRESS_ADDRESS1":              "text",
+	"CUSTOMER_ADDRESS_ADDRESS2":              "text",
+	"CUSTOMER_ADDRESS_ADDRESS3":              "text",
+	"CUSTOMER_ADDRESS_FULL_NAME":             "text",
+	"CUSTOMER_ADDRESS_COMPANY":               "text",
+	"CUSTOMER_ADDRESS_POSTCODE":              "text",
+	"CUSTOMER_ADDRESS_COUNTY":                "text",
+	"CUSTOMER_ADDRESS_TOWN":                  "text",
+	"CUSTOMER_EMAIL":                         "text",
+	"CUSTOMER_CHANNEL_BUYER_NAME":            "text",
+	"CUSTOMER_PHONE":                         "text",
+	"CUSTOMER_BILLING_ADDRESS_NAME":          "text",
+	"CUSTOMER_BILLING_ADDRESS_COMPANY":       "text",
+	"CUSTOMER_BILLING_ADDRESS_ADDRESS1":      "text",
+	"CUSTOMER_BILLING_ADDRESS_ADDRESS2":      "text",
+	"CUSTOMER_BILLING_ADDRESS_ADDRESS3":      "text",
+	"CUSTOMER_BILLING_ADDRESS_TOWN":          "text",
+	"CUSTOMER_BILLING_ADDRESS_REGION":        "text",
+	"CUSTOMER_BILLING_ADDRESS_POSTCODE":      "text",
+	"CUSTOMER_BILLING_EMAIL":                 "text",
+	"CUSTOMER_BILLING_PHONE":                 "text",
+	"ITEMS_SKU":                              "text",
+	"ITEMS_ORIGINAL_SKU":                     "text",
+	"ITEMS_TITLE":                            "text",
+	"ITEMS_ORIGINAL_TITLE":                   "text",
+	"ITEMS_ITEM_NUMBER":                      "text",
+	"ITEMS_SOURCE":                           "text",
+	"ITEMS_BINRACK":                          "text",
+	"ITEMS_BARCODE_NUMBER":                   "text",
+	"ITEMS_STOCKITEM_ID":                     "text",
+	"ITEMS_COMPOSITE_PARENT_ID":              "text",
+	"JOB":                                    "text",
+	"HOT_BUTTON":                             "text",
+	"FULFILLMENT_ADDITIONAL":                 "text",
+}
+
+func (b *FieldsFilterBuilder) Build() (*models.FieldsFilter, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	if len(b.err) > 0 {
+		return nil, errors.Join(b.err...)
+	}
+	return b.data, nil
+}
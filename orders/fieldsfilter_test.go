@@ -0,0 +1,71 @@
+package orders_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MMC-BK/lw-api/orders"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+func TestFieldsFilterBuilder(t *testing.T) {
+	t.Run("should build filters of every kind", func(t *testing.T) {
+		now := time.Now()
+		filter, err := orders.NewFieldsFilterBuilder().
+			TextEqual(models.TextFieldFilterFieldCodeGENERALINFOREFERENCENUMBER, "A-1").
+			NumericGreater(models.NumericFieldFilterFieldCodeTOTALSTOTAL, 10).
+			DateRange(models.DateFieldFilterFieldCodeGENERALINFODATE, now.AddDate(0, 0, -7), now).
+			ListIs(models.ListFieldFilterFieldCodeGENERALINFOSOURCE, "EBAY").
+			Boolean(models.BooleanFieldFilterFieldCodeGENERALINFOPARKED, false).
+			Build()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if len(filter.TextFields) != 1 || len(filter.NumericFields) != 1 || len(filter.DateFields) != 1 ||
+			len(filter.ListFields) != 1 || len(filter.BooleanFields) != 1 {
+			t.Errorf("unexpected filter %+v", filter)
+		}
+	})
+
+	t.Run("should reject a field with the wrong filter kind", func(t *testing.T) {
+		_, err := orders.NewFieldsFilterBuilder().
+			TextEqual(models.TextFieldFilterFieldCodeGENERALINFODATE, "2024-01-01").
+			DateLastDays(models.DateFieldFilterFieldCodeGENERALINFOREFERENCENUMBER, 3).
+			Build()
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+		for _, want := range []string{"text filter GENERAL_INFO_DATE: field takes a date filter", "date filter GENERAL_INFO_REFERENCE_NUMBER: field takes a text filter"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected %q in %v", want, err)
+			}
+		}
+	})
+
+	t.Run("should reject group codes and unknown codes", func(t *testing.T) {
+		_, err := orders.NewFieldsFilterBuilder().
+			TextContains(models.TextFieldFilterFieldCodeGENERALINFO, "x").
+			NumericEqual("NOT_A_FIELD", 1).
+			Build()
+		if err == nil || !strings.Contains(err.Error(), "field cannot be filtered") || !strings.Contains(err.Error(), "NOT_A_FIELD") {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("should validate values", func(t *testing.T) {
+		now := time.Now()
+		_, err := orders.NewFieldsFilterBuilder().
+			TextEqual(models.TextFieldFilterFieldCodeITEMSSKU, "").
+			DateRange(models.DateFieldFilterFieldCodeGENERALINFODATE, now, now.Add(-time.Hour)).
+			DateOlderThan(models.DateFieldFilterFieldCodeGENERALINFODATE, 0).
+			ListNot(models.ListFieldFilterFieldCodeGENERALINFOSOURCE, "").
+			Build()
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+		if n := strings.Count(err.Error(), "\n") + 1; n != 4 {
+			t.Errorf("Expected 4 errors, got %d: %v", n, err)
+		}
+	})
+}
@@ -0,0 +1,219 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-openapi/strfmt"
+
+	lw_api "github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/orders/models"
+	"github.com/MMC-BK/lw-api/paging"
+)
+
+type GetOpenOrdersRequestBuilder struct {
+	ctx     context.Context
+	client  lw_api.MakeRequest
+	data    *models.OrdersGetOpenOrdersRequest
+	filters *FieldsFilterBuilder
+	err     []error
+	opts    []lw_api.CallOption
+}
+
+func (o Orders) GetOpenOrders(ctx context.Context) *GetOpenOrdersRequestBuilder {
+	return &GetOpenOrdersRequestBuilder{
+		ctx:    ctx,
+		client: o.c,
+		data:   &models.OrdersGetOpenOrdersRequest{},
+		err:    make([]error, 0),
+	}
+}
+
+func (b *GetOpenOrdersRequestBuilder) EntriesPerPage(value int32) *GetOpenOrdersRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	if value <= 0 {
+		b.err = append(b.err, errors.New("entriesPerPage must be greater than 0"))
+		return b
+	}
+	b.data.EntriesPerPage = value
+	return b
+}
+
+func (b *GetOpenOrdersRequestBuilder) PageNumber(page int32) *GetOpenOrdersRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	if page <= 0 {
+		b.err = append(b.err, errors.New("pageNumber must be greater than 0"))
+		return b
+	}
+	b.data.PageNumber = page
+	return b
+}
+
+func (b *GetOpenOrdersRequestBuilder) FulfilmentCenter(location strfmt.UUID) *GetOpenOrdersRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	if !strfmt.IsUUID(location.String()) {
+		b.err = append(b.err, fmt.Errorf("fulfilmentCenter %q is not a valid uuid", location))
+		return b
+	}
+	b.data.FulfilmentCenter = location
+	return b
+}
+
+func (b *GetOpenOrdersRequestBuilder) AdditionalFilter(value string) *GetOpenOrdersRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	b.data.AdditionalFilter = value
+	return b
+}
+
+// Filters attaches a filter builder; its errors are reported by Do.
+func (b *GetOpenOrdersRequestBuilder) Filters(filters *FieldsFilterBuilder) *GetOpenOrdersRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	if filters == nil {
+		b.err = append(b.err, errors.New("filters cannot be nil"))
+		return b
+	}
+	b.filters = filters
+	return b
+}
+
+// SortASC and SortDESC append sorting entries; call order defines sorting priority.
+func (b *GetOpenOrdersRequestBuilder) SortASC(fieldCode string) *GetOpenOrdersRequestBuilder {
+	return b.sorting(models.FieldSortingDirectionAscending, fieldCode)
+}
+
+func (b *GetOpenOrdersRequestBuilder) SortDESC(fieldCode string) *GetOpenOrdersRequestBuilder {
+	return b.sorting(models.FieldSortingDirectionDescending, fieldCode)
+}
+
+func (b *GetOpenOrdersRequestBuilder) sorting(direction, fieldCode string) *GetOpenOrdersRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	if fieldCode == "" {
+		b.err = append(b.err, errors.New("sorting field code is required"))
+		return b
+	}
+	for _, s := range b.data.Sorting {
+		if s.FieldCode == fieldCode {
+			b.err = append(b.err, fmt.Errorf("sorting by %s might be used once", fieldCode))
+			return b
+		}
+	}
+	sorting := &models.FieldSorting{
+		Direction: direction,
+		FieldCode: fieldCode,
+		Order:     int32(len(b.data.Sorting)),
+	}
+	if err := sorting.Validate(strfmt.NewFormats()); err != nil {
+		b.err = append(b.err, err)
+		return b
+	}
+	b.data.Sorting = append(b.data.Sorting, sorting)
+	return b
+}
+
+// RetryPolicy overrides the client retry policy for this call.
+func (b *GetOpenOrdersRequestBuilder) RetryPolicy(policy lw_api.RetryPolicy) *GetOpenOrdersRequestBuilder {
+	return b.WithOptions(lw_api.CallRetryPolicy(policy))
+}
+
+// WithOptions applies per-call options such as a timeout, extra headers or a rate limit priority.
+func (b *GetOpenOrdersRequestBuilder) WithOptions(opts ...lw_api.CallOption) *GetOpenOrdersRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	b.opts = append(b.opts, opts...)
+	return b
+}
+
+func (b *GetOpenOrdersRequestBuilder) requestContext(ctx context.Context) context.Context {
+	return lw_api.WithCallOptions(ctx, b.opts...)
+}
+
+func (b *GetOpenOrdersRequestBuilder) build() (*models.OrdersGetOpenOrdersRequest, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	errs := make([]error, len(b.err))
+	copy(errs, b.err)
+	if b.filters != nil {
+		filters, err := b.filters.Build()
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			b.data.Filters = filters
+		}
+	}
+	if b.data.EntriesPerPage == 0 {
+		errs = append(errs, errors.New("entriesPerPage is required"))
+	}
+	if b.data.PageNumber == 0 {
+		errs = append(errs, errors.New("pageNumber is required"))
+	}
+	errs = append(errs, lw_api.ValidatePayload(b.ctx, b.data)...)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return b.data, nil
+}
+
+func (b *GetOpenOrdersRequestBuilder) Do() (*models.GenericPagedResultOpenOrder, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	return b.do(b.ctx)
+}
+
+// DoRaw sends the request and returns the undecoded response with its status and headers.
+func (b *GetOpenOrdersRequestBuilder) DoRaw() (*lw_api.Response, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	req, err := b.build()
+	if err != nil {
+		return nil, err
+	}
+	return lw_api.DoRaw(b.requestContext(b.ctx), b.client, http.MethodPost, "/api/Orders/GetOpenOrders", nil, req)
+}
+
+// Pager walks all result pages starting from the configured page number.
+func (b *GetOpenOrdersRequestBuilder) Pager(opts ...paging.Option) *paging.Pager[*models.OpenOrder] {
+	if b == nil {
+		return nil
+	}
+	if b.data.PageNumber > 0 {
+		opts = append([]paging.Option{paging.StartPage(b.data.PageNumber)}, opts...)
+	}
+	return paging.New(func(ctx context.Context, page int32) (paging.Page[*models.OpenOrder], error) {
+		b.data.PageNumber = page
+		out, err := b.do(ctx)
+		if err != nil {
+			return paging.Page[*models.OpenOrder]{}, err
+		}
+		return paging.FromOpenOrder(out), nil
+	}, opts...)
+}
+
+func (b *GetOpenOrdersRequestBuilder) do(ctx context.Context) (*models.GenericPagedResultOpenOrder, error) {
+	req, err := b.build()
+	if err != nil {
+		return nil, err
+	}
+	var out models.GenericPagedResultOpenOrder
+	if err := b.client.DoJSON(b.requestContext(ctx), http.MethodPost, "/api/Orders/GetOpenOrders", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -19,6 +19,7 @@ type GetOrderDetailsByNumOrderIdRequestBuilder struct {
 	client lw_api.MakeRequest
 	data   *GetOrderDetailsByNumOrderIdRequest
 	err    []error
+	opts   []lw_api.CallOption
 }
 
 func (o Orders) GetOrderDetailsByNumOrderId(ctx context.Context) *GetOrderDetailsByNumOrderIdRequestBuilder {
@@ -43,6 +44,24 @@ func (b *GetOrderDetailsByNumOrderIdRequestBuilder) OrderID(orderID int32) *GetO
 	return b
 }
 
+// RetryPolicy overrides the client retry policy for this call.
+func (b *GetOrderDetailsByNumOrderIdRequestBuilder) RetryPolicy(policy lw_api.RetryPolicy) *GetOrderDetailsByNumOrderIdRequestBuilder {
+	return b.WithOptions(lw_api.CallRetryPolicy(policy))
+}
+
+// WithOptions applies per-call options such as a timeout, extra headers or a rate limit priority.
+func (b *GetOrderDetailsByNumOrderIdRequestBuilder) WithOptions(opts ...lw_api.CallOption) *GetOrderDetailsByNumOrderIdRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	b.opts = append(b.opts, opts...)
+	return b
+}
+
+func (b *GetOrderDetailsByNumOrderIdRequestBuilder) requestContext(ctx context.Context) context.Context {
+	return lw_api.WithCallOptions(ctx, b.opts...)
+}
+
 func (b *GetOrderDetailsByNumOrderIdRequestBuilder) build() (*GetOrderDetailsByNumOrderIdRequest, error) {
 	if b == nil {
 		return nil, errors.New("builder is nil")
@@ -58,6 +77,12 @@ func (b *GetOrderDetailsByNumOrderIdRequestBuilder) build() (*GetOrderDetailsByN
 	return b.data, nil
 }
 
+func (b *GetOrderDetailsByNumOrderIdRequestBuilder) query(req *GetOrderDetailsByNumOrderIdRequest) url.Values {
+	query := url.Values{}
+	query.Set("OrderId", strconv.FormatInt(int64(*req.OrderID), 10))
+	return query
+}
+
 func (b *GetOrderDetailsByNumOrderIdRequestBuilder) Do() (*models.OrderDetails, error) {
 	if b == nil {
 		return nil, errors.New("builder is nil")
@@ -66,14 +91,22 @@ func (b *GetOrderDetailsByNumOrderIdRequestBuilder) Do() (*models.OrderDetails,
 	if err != nil {
 		return nil, err
 	}
-	query := url.Values{}
-	query.Set("OrderId", strconv.FormatInt(int64(*req.OrderID), 10))
-	path := "/api/Orders/GetOrderDetailsByNumOrderId"
-
 	var out models.OrderDetails
-	if err := b.client.DoJSON(b.ctx, http.MethodGet, path, query, nil, &out); err != nil {
+	if err := b.client.DoJSON(b.requestContext(b.ctx), http.MethodGet, "/api/Orders/GetOrderDetailsByNumOrderId", b.query(req), nil, &out); err != nil {
 		return nil, err
 	}
 
 	return &out, nil
 }
+
+// DoRaw sends the request and returns the undecoded response with its status and headers.
+func (b *GetOrderDetailsByNumOrderIdRequestBuilder) DoRaw() (*lw_api.Response, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	req, err := b.build()
+	if err != nil {
+		return nil, err
+	}
+	return lw_api.DoRaw(b.requestContext(b.ctx), b.client, http.MethodGet, "/api/Orders/GetOrderDetailsByNumOrderId", b.query(req), nil)
+}
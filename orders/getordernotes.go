@@ -0,0 +1,11 @@
+package orders
+
+import "errors"
+
+func (b *GetOrderNotesRequestBuilder) requiredField() []error {
+	var errs []error
+	if !b.query.Has("orderId") {
+		errs = append(errs, errors.New("orderId is required"))
+	}
+	return errs
+}
@@ -16,6 +16,7 @@ type GetOrdersByIdRequestBuilder struct {
 	client lw_api.MakeRequest
 	data   *models.OrdersGetOrdersByIDRequest
 	err    []error
+	opts   []lw_api.CallOption
 }
 
 func (o Orders) GetOrdersById(ctx context.Context) *GetOrdersByIdRequestBuilder {
@@ -40,6 +41,24 @@ func (b *GetOrdersByIdRequestBuilder) PkOrderIds(ids []strfmt.UUID) *GetOrdersBy
 	return b
 }
 
+// RetryPolicy overrides the client retry policy for this call.
+func (b *GetOrdersByIdRequestBuilder) RetryPolicy(policy lw_api.RetryPolicy) *GetOrdersByIdRequestBuilder {
+	return b.WithOptions(lw_api.CallRetryPolicy(policy))
+}
+
+// WithOptions applies per-call options such as a timeout, extra headers or a rate limit priority.
+func (b *GetOrdersByIdRequestBuilder) WithOptions(opts ...lw_api.CallOption) *GetOrdersByIdRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	b.opts = append(b.opts, opts...)
+	return b
+}
+
+func (b *GetOrdersByIdRequestBuilder) requestContext(ctx context.Context) context.Context {
+	return lw_api.WithCallOptions(ctx, b.opts...)
+}
+
 func (b *GetOrdersByIdRequestBuilder) build() (*models.OrdersGetOrdersByIDRequest, error) {
 	if b == nil {
 		return nil, errors.New("builder is nil")
@@ -50,6 +69,7 @@ func (b *GetOrdersByIdRequestBuilder) build() (*models.OrdersGetOrdersByIDReques
 	if len(b.data.PkOrderIds) == 0 {
 		errs = append(errs, errors.New("pkOrderIds must contain at least one value"))
 	}
+	errs = append(errs, lw_api.ValidatePayload(b.ctx, b.data)...)
 	if len(errs) > 0 {
 		return nil, errors.Join(errs...)
 	}
@@ -65,12 +85,24 @@ func (b *GetOrdersByIdRequestBuilder) Do() ([]models.OrderDetails, error) {
 		return nil, err
 	}
 	var out []models.OrderDetails
-	if err := b.client.DoJSON(b.ctx, http.MethodPost, "/api/Orders/GetOrdersById", nil, req, &out); err != nil {
+	if err := b.client.DoJSON(b.requestContext(b.ctx), http.MethodPost, "/api/Orders/GetOrdersById", nil, req, &out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
+// DoRaw sends the request and returns the undecoded response with its status and headers.
+func (b *GetOrdersByIdRequestBuilder) DoRaw() (*lw_api.Response, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	req, err := b.build()
+	if err != nil {
+		return nil, err
+	}
+	return lw_api.DoRaw(b.requestContext(b.ctx), b.client, http.MethodPost, "/api/Orders/GetOrdersById", nil, req)
+}
+
 func (b *GetOrdersByIdRequestBuilder) ensureData() {
 	if b.data == nil {
 		b.data = &models.OrdersGetOrdersByIDRequest{}
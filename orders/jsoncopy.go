@@ -0,0 +1,40 @@
+package orders
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// cloneJSON deep-copies v through its JSON form, which is all Linnworks ever sees of it. It
+// fails on values JSON cannot carry, such as a NaN price.
+func cloneJSON[T any](v *T) (*T, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out T
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// changed compares the JSON forms, so a nil section equals an empty one.
+func changed[T any](before, after *T) (bool, error) {
+	if before == nil {
+		var zero T
+		before = &zero
+	}
+	x, err := json.Marshal(before)
+	if err != nil {
+		return false, err
+	}
+	y, err := json.Marshal(after)
+	if err != nil {
+		return false, err
+	}
+	return !bytes.Equal(x, y), nil
+}
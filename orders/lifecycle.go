@@ -0,0 +1,401 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-openapi/strfmt"
+
+	lw_api "github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+// Payment statuses of OrderGeneralInfo.Status.
+const (
+	OrderStatusUnpaid int32 = iota
+	OrderStatusPaid
+	OrderStatusReturn
+	OrderStatusPending
+	OrderStatusResend
+)
+
+// OrderState is where an order is in its lifecycle. Linnworks has no separate lock flag:
+// HoldOrCancel means on hold for an open order and cancelled for a processed one.
+type OrderState string
+
+const (
+	OrderStateOpen      OrderState = "open"
+	OrderStateLocked    OrderState = "locked"
+	OrderStateProcessed OrderState = "processed"
+	OrderStateCancelled OrderState = "cancelled"
+	// OrderStateUnknown is the state of a nil order; it allows no action.
+	OrderStateUnknown OrderState = "unknown"
+)
+
+// StateOf derives the lifecycle state from Processed and GeneralInfo.HoldOrCancel.
+func StateOf(o *models.OrderDetails) OrderState {
+	if o == nil {
+		return OrderStateUnknown
+	}
+	held := o.GeneralInfo != nil && o.GeneralInfo.HoldOrCancel
+	switch {
+	case o.Processed && held:
+		return OrderStateCancelled
+	case o.Processed:
+		return OrderStateProcessed
+	case held:
+		return OrderStateLocked
+	default:
+		return OrderStateOpen
+	}
+}
+
+// OrderAction is a lifecycle transition sent by TransitionOrders.
+type OrderAction string
+
+const (
+	ActionLock         OrderAction = "lock"
+	ActionUnlock       OrderAction = "unlock"
+	ActionCancel       OrderAction = "cancel"
+	ActionDelete       OrderAction = "delete"
+	ActionChangeStatus OrderAction = "change status"
+	ActionComplete     OrderAction = "complete"
+)
+
+// transitions lists the actions allowed in each state. Processed and cancelled orders accept
+// none; a locked order has to be unlocked before anything but a cancel.
+var transitions = map[OrderState][]OrderAction{
+	OrderStateOpen:   {ActionLock, ActionCancel, ActionDelete, ActionChangeStatus, ActionComplete},
+	OrderStateLocked: {ActionUnlock, ActionCancel},
+}
+
+// CanTransition reports whether action may be sent for an order in state.
+func CanTransition(state OrderState, action OrderAction) bool {
+	for _, a := range transitions[state] {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// getOrdersByIDLimit is the most ids GetOrdersById accepts in one call.
+const getOrdersByIDLimit = 100
+
+// ErrTransitionNotAllowed matches every *TransitionError with errors.Is.
+var ErrTransitionNotAllowed = errors.New("order transition not allowed")
+
+// TransitionError is returned for an action the order's state does not allow. Nothing is sent
+// for the order.
+type TransitionError struct {
+	OrderID    strfmt.UUID
+	NumOrderID int32
+	Action     OrderAction
+	State      OrderState
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("cannot %s order %d (%s): order is %s", e.Action, e.NumOrderID, e.OrderID, e.State)
+}
+
+func (e *TransitionError) Is(target error) bool { return target == ErrTransitionNotAllowed }
+
+// TransitionOutcome is an order that was not moved and why.
+type TransitionOutcome struct {
+	OrderID strfmt.UUID
+	// Err is a *TransitionError for rejected orders and the call error for failed ones.
+	Err error
+}
+
+// TransitionReport sorts the orders passed to TransitionOrders by what happened to them.
+type TransitionReport struct {
+	Action    OrderAction
+	Succeeded []strfmt.UUID
+	// Unchanged holds orders already in the target state, e.g. locking a locked order. Nothing
+	// is sent for them.
+	Unchanged []strfmt.UUID
+	// Rejected holds orders the state machine refused and orders that were not found.
+	Rejected []TransitionOutcome
+	// Failed holds orders whose Linnworks call failed.
+	Failed []TransitionOutcome
+}
+
+// Err joins the rejections and failures into one error; nil when every order was moved or
+// already in place. Rejections keep their *TransitionError for errors.As.
+func (r *TransitionReport) Err() error {
+	if r == nil {
+		return nil
+	}
+	var errs []error
+	for _, o := range r.Rejected {
+		errs = append(errs, o.Err)
+	}
+	for _, o := range r.Failed {
+		errs = append(errs, fmt.Errorf("order %s: %w", o.OrderID, o.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// OrderTransitionBuilder loads the orders, checks each against the state machine and sends one
+// action for the ones it allows. Lock, unlock and status changes go out in one call; cancel,
+// delete and complete are sent per order.
+//
+//	report, err := api.TransitionOrders(ctx).OrderIds(ids).Cancel().Note("out of stock").Do()
+//	if err == nil {
+//		err = report.Err()
+//	}
+type OrderTransitionBuilder struct {
+	ctx              context.Context
+	orders           Orders
+	ids              []strfmt.UUID
+	action           OrderAction
+	status           int32
+	fulfilmentCenter strfmt.UUID
+	refund           float64
+	note             string
+	err              []error
+	opts             []lw_api.CallOption
+}
+
+func (o Orders) TransitionOrders(ctx context.Context) *OrderTransitionBuilder {
+	return &OrderTransitionBuilder{
+		ctx:    ctx,
+		orders: o,
+		err:    make([]error, 0),
+	}
+}
+
+// OrderIds appends orders to move. Repeated ids are sent once.
+func (b *OrderTransitionBuilder) OrderIds(ids []strfmt.UUID) *OrderTransitionBuilder {
+	if b == nil {
+		return nil
+	}
+	for _, id := range ids {
+		if !strfmt.IsUUID(id.String()) {
+			b.err = append(b.err, fmt.Errorf("orderId %q is not a valid uuid", id))
+			return b
+		}
+	}
+	b.ids = append(b.ids, ids...)
+	return b
+}
+
+func (b *OrderTransitionBuilder) Lock() *OrderTransitionBuilder { return b.setAction(ActionLock) }
+
+func (b *OrderTransitionBuilder) Unlock() *OrderTransitionBuilder { return b.setAction(ActionUnlock) }
+
+// Cancel cancels the orders; see Refund, Note and FulfilmentCenter.
+func (b *OrderTransitionBuilder) Cancel() *OrderTransitionBuilder { return b.setAction(ActionCancel) }
+
+func (b *OrderTransitionBuilder) Delete() *OrderTransitionBuilder { return b.setAction(ActionDelete) }
+
+func (b *OrderTransitionBuilder) Complete() *OrderTransitionBuilder {
+	return b.setAction(ActionComplete)
+}
+
+// ChangeStatus sets the payment status, see the OrderStatus constants.
+func (b *OrderTransitionBuilder) ChangeStatus(status int32) *OrderTransitionBuilder {
+	if b == nil {
+		return nil
+	}
+	if status < OrderStatusUnpaid || status > OrderStatusResend {
+		b.err = append(b.err, fmt.Errorf("status %d is not a known order status", status))
+		return b
+	}
+	b.status = status
+	return b.setAction(ActionChangeStatus)
+}
+
+// FulfilmentCenter overrides the location of each order for Cancel.
+func (b *OrderTransitionBuilder) FulfilmentCenter(location strfmt.UUID) *OrderTransitionBuilder {
+	if b == nil {
+		return nil
+	}
+	if !strfmt.IsUUID(location.String()) {
+		b.err = append(b.err, fmt.Errorf("fulfilmentCenter %q is not a valid uuid", location))
+		return b
+	}
+	b.fulfilmentCenter = location
+	return b
+}
+
+// Refund is sent with every Cancel.
+func (b *OrderTransitionBuilder) Refund(amount float64) *OrderTransitionBuilder {
+	if b == nil {
+		return nil
+	}
+	if amount < 0 {
+		b.err = append(b.err, errors.New("refund must not be negative"))
+		return b
+	}
+	b.refund = amount
+	return b
+}
+
+// Note is attached to every cancelled order.
+func (b *OrderTransitionBuilder) Note(text string) *OrderTransitionBuilder {
+	if b == nil {
+		return nil
+	}
+	b.note = text
+	return b
+}
+
+func (b *OrderTransitionBuilder) setAction(action OrderAction) *OrderTransitionBuilder {
+	if b == nil {
+		return nil
+	}
+	if b.action != "" && b.action != action {
+		b.err = append(b.err, fmt.Errorf("cannot %s and %s in one call", b.action, action))
+		return b
+	}
+	b.action = action
+	return b
+}
+
+// RetryPolicy overrides the client retry policy for every call.
+func (b *OrderTransitionBuilder) RetryPolicy(policy lw_api.RetryPolicy) *OrderTransitionBuilder {
+	return b.WithOptions(lw_api.CallRetryPolicy(policy))
+}
+
+// WithOptions applies per-call options to every call.
+func (b *OrderTransitionBuilder) WithOptions(opts ...lw_api.CallOption) *OrderTransitionBuilder {
+	if b == nil {
+		return nil
+	}
+	b.opts = append(b.opts, opts...)
+	return b
+}
+
+func (b *OrderTransitionBuilder) build() error {
+	if b == nil {
+		return errors.New("builder is nil")
+	}
+	errs := make([]error, len(b.err))
+	copy(errs, b.err)
+	if len(b.ids) == 0 {
+		errs = append(errs, errors.New("orderIds must contain at least one value"))
+	}
+	if b.action == "" {
+		errs = append(errs, errors.New("an action is required: Lock, Unlock, Cancel, Delete, ChangeStatus or Complete"))
+	}
+	return errors.Join(errs...)
+}
+
+// Do checks and sends the action. The error is only about the builder and loading the orders:
+// rejected and failed orders end up in the report.
+func (b *OrderTransitionBuilder) Do() (*TransitionReport, error) {
+	if err := b.build(); err != nil {
+		return nil, err
+	}
+	ids := make([]strfmt.UUID, 0, len(b.ids))
+	seen := make(map[strfmt.UUID]bool, len(b.ids))
+	for _, id := range b.ids {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	byID := make(map[strfmt.UUID]*models.OrderDetails, len(ids))
+	for start := 0; start < len(ids); start += getOrdersByIDLimit {
+		found, err := b.orders.GetOrdersById(b.ctx).PkOrderIds(ids[start:min(start+getOrdersByIDLimit, len(ids))]).WithOptions(b.opts...).Do()
+		if err != nil {
+			return nil, fmt.Errorf("load orders: %w", err)
+		}
+		for i := range found {
+			byID[found[i].OrderID] = &found[i]
+		}
+	}
+
+	report := &TransitionReport{Action: b.action}
+	var allowed []*models.OrderDetails
+	for _, id := range ids {
+		o, ok := byID[id]
+		switch {
+		case !ok:
+			report.Rejected = append(report.Rejected, TransitionOutcome{OrderID: id, Err: fmt.Errorf("order %s not found", id)})
+		case b.unchanged(o):
+			report.Unchanged = append(report.Unchanged, id)
+		case !CanTransition(StateOf(o), b.action):
+			report.Rejected = append(report.Rejected, TransitionOutcome{OrderID: id, Err: &TransitionError{
+				OrderID:    id,
+				NumOrderID: o.NumOrderID,
+				Action:     b.action,
+				State:      StateOf(o),
+			}})
+		default:
+			allowed = append(allowed, o)
+		}
+	}
+	if len(allowed) == 0 {
+		return report, nil
+	}
+
+	switch b.action {
+	case ActionLock, ActionUnlock, ActionChangeStatus:
+		batch := make([]strfmt.UUID, len(allowed))
+		for i, o := range allowed {
+			batch[i] = o.OrderID
+		}
+		if err := b.sendBatch(batch); err != nil {
+			for _, id := range batch {
+				report.Failed = append(report.Failed, TransitionOutcome{OrderID: id, Err: err})
+			}
+			return report, nil
+		}
+		report.Succeeded = append(report.Succeeded, batch...)
+	default:
+		for _, o := range allowed {
+			if err := b.sendOne(o); err != nil {
+				report.Failed = append(report.Failed, TransitionOutcome{OrderID: o.OrderID, Err: err})
+				continue
+			}
+			report.Succeeded = append(report.Succeeded, o.OrderID)
+		}
+	}
+	return report, nil
+}
+
+// unchanged reports whether the order is already where the action would take it.
+func (b *OrderTransitionBuilder) unchanged(o *models.OrderDetails) bool {
+	switch state := StateOf(o); b.action {
+	case ActionLock:
+		return state == OrderStateLocked
+	case ActionUnlock:
+		return state == OrderStateOpen
+	case ActionChangeStatus:
+		return state == OrderStateOpen && o.GeneralInfo != nil && o.GeneralInfo.Status == b.status
+	default:
+		return false
+	}
+}
+
+func (b *OrderTransitionBuilder) sendBatch(ids []strfmt.UUID) error {
+	if b.action == ActionChangeStatus {
+		return b.orders.ChangeStatus(b.ctx).OrderIds(ids).Status(b.status).WithOptions(b.opts...).Do()
+	}
+	return b.orders.LockOrder(b.ctx).OrderIds(ids).LockOrder(b.action == ActionLock).WithOptions(b.opts...).Do()
+}
+
+func (b *OrderTransitionBuilder) sendOne(o *models.OrderDetails) error {
+	switch b.action {
+	case ActionCancel:
+		location := b.fulfilmentCenter
+		if location == "" {
+			location = o.FulfilmentLocationID
+		}
+		if location == "" && o.GeneralInfo != nil {
+			location = o.GeneralInfo.Location
+		}
+		req := b.orders.CancelOrder(b.ctx).OrderID(o.OrderID).Refund(b.refund).Note(b.note).WithOptions(b.opts...)
+		if location != "" {
+			req.FulfilmentCenter(location)
+		}
+		return req.Do()
+	case ActionDelete:
+		return b.orders.DeleteOrder(b.ctx).OrderID(o.OrderID).WithOptions(b.opts...).Do()
+	default:
+		return b.orders.CompleteOrder(b.ctx).OrderID(o.OrderID).WithOptions(b.opts...).Do()
+	}
+}
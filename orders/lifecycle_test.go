@@ -0,0 +1,201 @@
+package orders_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/MMC-BK/lw-api/lwmock"
+	"github.com/MMC-BK/lw-api/orders"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+// lifecycle stubs GetOrdersById with an open, a locked, a processed and a cancelled order.
+func lifecycle(m *lwmock.API) []strfmt.UUID {
+	ids := orderIDs(4)
+	m.OnGetOrdersById(func(req *models.OrdersGetOrdersByIDRequest) ([]models.OrderDetails, error) {
+		all := []models.OrderDetails{
+			{OrderID: ids[0], NumOrderID: 1001, FulfilmentLocationID: "00000000-0000-0000-0000-000000000000", GeneralInfo: &models.OrderGeneralInfo{Status: orders.OrderStatusPaid}},
+			{OrderID: ids[1], NumOrderID: 1002, GeneralInfo: &models.OrderGeneralInfo{HoldOrCancel: true}},
+			{OrderID: ids[2], NumOrderID: 1003, Processed: true},
+			{OrderID: ids[3], NumOrderID: 1004, Processed: true, GeneralInfo: &models.OrderGeneralInfo{HoldOrCancel: true}},
+		}
+		var out []models.OrderDetails
+		for _, o := range all {
+			if slices.Contains(req.PkOrderIds, o.OrderID) {
+				out = append(out, o)
+			}
+		}
+		return out, nil
+	})
+	return ids
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should derive states and allowed transitions", func(t *testing.T) {
+		cases := []struct {
+			order models.OrderDetails
+			state orders.OrderState
+		}{
+			{models.OrderDetails{}, orders.OrderStateOpen},
+			{models.OrderDetails{GeneralInfo: &models.OrderGeneralInfo{HoldOrCancel: true}}, orders.OrderStateLocked},
+			{models.OrderDetails{Processed: true}, orders.OrderStateProcessed},
+			{models.OrderDetails{Processed: true, GeneralInfo: &models.OrderGeneralInfo{HoldOrCancel: true}}, orders.OrderStateCancelled},
+		}
+		for _, c := range cases {
+			if got := orders.StateOf(&c.order); got != c.state {
+				t.Errorf("Expected %s, got %s", c.state, got)
+			}
+		}
+		if got := orders.StateOf(nil); got != orders.OrderStateUnknown || orders.CanTransition(got, orders.ActionUnlock) {
+			t.Errorf("Expected a nil order to be unknown and stay put, got %s", got)
+		}
+		if orders.CanTransition(orders.OrderStateProcessed, orders.ActionCancel) {
+			t.Error("Expected processed orders not to be cancellable")
+		}
+		if orders.CanTransition(orders.OrderStateLocked, orders.ActionDelete) {
+			t.Error("Expected locked orders not to be deletable")
+		}
+		if !orders.CanTransition(orders.OrderStateLocked, orders.ActionUnlock) {
+			t.Error("Expected locked orders to be unlockable")
+		}
+	})
+
+	t.Run("should cancel open and locked orders and reject the rest", func(t *testing.T) {
+		m := lwmock.New()
+		ids := lifecycle(m)
+		m.OnCancelOrder(func(req *models.OrdersCancelOrderRequest) error {
+			if req.FulfilmentCenter == "" || req.Note != "out of stock" {
+				t.Errorf("unexpected cancel %+v", req)
+			}
+			return nil
+		})
+		report, err := m.TransitionOrders(ctx).OrderIds(ids).Cancel().Note("out of stock").FulfilmentCenter("00000000-0000-0000-0000-000000000000").Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if !slices.Equal(report.Succeeded, ids[:2]) || len(report.Rejected) != 2 {
+			t.Errorf("unexpected report %+v", report)
+		}
+		var te *orders.TransitionError
+		if !errors.As(report.Err(), &te) || te.NumOrderID != 1003 || te.State != orders.OrderStateProcessed {
+			t.Errorf("Expected transition error for order 1003, got %v", report.Err())
+		}
+		if !errors.Is(report.Err(), orders.ErrTransitionNotAllowed) {
+			t.Error("Expected ErrTransitionNotAllowed")
+		}
+	})
+
+	t.Run("should refuse to delete a locked order", func(t *testing.T) {
+		m := lwmock.New()
+		ids := lifecycle(m)
+		m.OnDeleteOrder(func(req *models.OrdersDeleteOrderRequest) error { return nil })
+		report, err := m.TransitionOrders(ctx).OrderIds(ids[1:2]).Delete().Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if len(report.Rejected) != 1 || !errors.Is(report.Rejected[0].Err, orders.ErrTransitionNotAllowed) {
+			t.Errorf("unexpected report %+v", report)
+		}
+		if n := len(m.Calls("/api/Orders/DeleteOrder")); n != 0 {
+			t.Errorf("Expected no delete calls, got %d", n)
+		}
+	})
+
+	t.Run("should lock in one call and skip orders already locked", func(t *testing.T) {
+		m := lwmock.New()
+		ids := lifecycle(m)
+		m.OnLockOrder(func(req *models.OrdersLockOrderRequest) error {
+			if !req.LockOrder || !slices.Equal(req.OrderIds, ids[:1]) {
+				t.Errorf("unexpected lock %+v", req)
+			}
+			return nil
+		})
+		report, err := m.TransitionOrders(ctx).OrderIds(ids[:2]).OrderIds(ids[:1]).Lock().Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if !slices.Equal(report.Succeeded, ids[:1]) || !slices.Equal(report.Unchanged, ids[1:2]) || report.Err() != nil {
+			t.Errorf("unexpected report %+v", report)
+		}
+		if n := len(m.Calls("/api/Orders/LockOrder")); n != 1 {
+			t.Errorf("Expected 1 lock call, got %d", n)
+		}
+	})
+
+	t.Run("should report failed calls per order", func(t *testing.T) {
+		m := lwmock.New()
+		ids := lifecycle(m)
+		m.OnChangeStatus(func(req *models.OrdersChangeStatusRequest) error {
+			return errors.New("boom")
+		})
+		report, err := m.TransitionOrders(ctx).OrderIds(ids[:1]).ChangeStatus(orders.OrderStatusPending).Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if len(report.Failed) != 1 || report.Failed[0].OrderID != ids[0] || report.Err() == nil {
+			t.Errorf("unexpected report %+v", report)
+		}
+		report, err = m.TransitionOrders(ctx).OrderIds(ids[:1]).ChangeStatus(orders.OrderStatusPaid).Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if len(report.Unchanged) != 1 {
+			t.Errorf("Expected the paid order to be unchanged, got %+v", report)
+		}
+	})
+
+	t.Run("should load orders in batches of 100", func(t *testing.T) {
+		m := lwmock.New()
+		ids := orderIDs(250)
+		m.OnGetOrdersById(func(req *models.OrdersGetOrdersByIDRequest) ([]models.OrderDetails, error) {
+			if len(req.PkOrderIds) > 100 {
+				t.Errorf("Expected at most 100 ids per call, got %d", len(req.PkOrderIds))
+			}
+			out := make([]models.OrderDetails, len(req.PkOrderIds))
+			for i, id := range req.PkOrderIds {
+				out[i] = models.OrderDetails{OrderID: id}
+			}
+			return out, nil
+		})
+		m.OnLockOrder(func(req *models.OrdersLockOrderRequest) error { return nil })
+		report, err := m.TransitionOrders(ctx).OrderIds(ids).Lock().Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if n := len(m.Calls("/api/Orders/GetOrdersById")); n != 3 {
+			t.Errorf("Expected 3 loads, got %d", n)
+		}
+		if len(report.Succeeded) != len(ids) {
+			t.Errorf("Expected %d locked orders, got %d", len(ids), len(report.Succeeded))
+		}
+	})
+
+	t.Run("should validate the builder", func(t *testing.T) {
+		m := lwmock.New()
+		if _, err := m.TransitionOrders(ctx).OrderIds(orderIDs(1)).Do(); err == nil {
+			t.Error("Expected missing action error, got nil")
+		}
+		if _, err := m.TransitionOrders(ctx).OrderIds(orderIDs(1)).Lock().Delete().Do(); err == nil {
+			t.Error("Expected conflicting actions error, got nil")
+		}
+		if _, err := m.TransitionOrders(ctx).OrderIds(orderIDs(1)).ChangeStatus(9).Do(); err == nil {
+			t.Error("Expected unknown status error, got nil")
+		}
+		if err := m.CancelOrder(ctx).OrderID(orderIDs(1)[0]).Do(); err == nil {
+			t.Error("Expected missing fulfilmentCenter error, got nil")
+		}
+		if err := m.ChangeStatus(ctx).OrderIds(orderIDs(1)).Do(); err == nil {
+			t.Error("Expected missing status error, got nil")
+		}
+		m.OnChangeStatus(func(req *models.OrdersChangeStatusRequest) error { return nil })
+		if err := m.ChangeStatus(ctx).OrderIds(orderIDs(1)).Status(orders.OrderStatusUnpaid).Do(); err != nil {
+			t.Errorf("Expected nil error, got %v", err)
+		}
+	})
+}
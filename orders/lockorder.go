@@ -0,0 +1,26 @@
+package orders
+
+import (
+	"context"
+	"errors"
+
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+// LockOrder locks the orders unless LockOrder(false) asks to unlock them.
+func (o Orders) LockOrder(ctx context.Context) *LockOrderRequestBuilder {
+	return &LockOrderRequestBuilder{
+		ctx:    ctx,
+		client: o.c,
+		data:   &models.OrdersLockOrderRequest{LockOrder: true},
+		err:    make([]error, 0),
+	}
+}
+
+func (b *LockOrderRequestBuilder) requiredField() []error {
+	var errs []error
+	if len(b.data.OrderIds) == 0 {
+		errs = append(errs, errors.New("orderIds is required"))
+	}
+	return errs
+}
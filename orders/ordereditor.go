@@ -0,0 +1,374 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-openapi/strfmt"
+
+	lw_api "github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+// NewOrderItem is a line for OrderEditor.Add.
+type NewOrderItem struct {
+	// ItemID is the stock item id.
+	ItemID     strfmt.UUID
+	ChannelSKU string
+	Quantity   int32
+	// Pricing overrides the inventory price of the line when set.
+	Pricing *models.LinePricingRequest
+}
+
+// OrderEditPlan lists the calls Save would send, in the order it sends them.
+type OrderEditPlan struct {
+	Remove []strfmt.UUID
+	Update []*models.OrderItem
+	Add    []NewOrderItem
+}
+
+// Empty reports whether there is nothing to send.
+func (p *OrderEditPlan) Empty() bool {
+	return p == nil || len(p.Remove)+len(p.Update)+len(p.Add) == 0
+}
+
+// OrderEditResult collects the responses of Save.
+type OrderEditResult struct {
+	Removed []*models.UpdateTotalsResult
+	Updated []*models.UpdateTotalsResult
+	Added   []*models.UpdateOrderItemResult
+	// Totals are the order totals Linnworks returned last; nil when nothing was sent.
+	Totals *models.OrderTotalsInfo
+}
+
+// OrderEditor edits the lines of an open order in memory. Save sends only what changed: one
+// RemoveOrderItem per removed line, one UpdateOrderItem per modified line and one AddOrderItem
+// per new line.
+//
+//	ed, err := api.EditOrder(ctx, 1001)
+//	res, err := ed.SetQuantity(rowID, 3).Remove(otherRowID).Save()
+type OrderEditor struct {
+	ctx              context.Context
+	orders           Orders
+	order            *models.OrderDetails
+	fulfilmentCenter strfmt.UUID
+	// rows keeps the order of the lines as loaded; base holds them as Linnworks has them and
+	// current as edited. A row missing from current is removed.
+	rows    []strfmt.UUID
+	base    map[strfmt.UUID]*models.OrderItem
+	current map[strfmt.UUID]*models.OrderItem
+	added   []NewOrderItem
+	err     []error
+	opts    []lw_api.CallOption
+}
+
+// EditOrder loads the order by its number and returns an editor for its lines.
+func (o Orders) EditOrder(ctx context.Context, numOrderID int32) (*OrderEditor, error) {
+	order, err := o.GetOrderDetailsByNumOrderId(ctx).OrderID(numOrderID).Do()
+	if err != nil {
+		return nil, err
+	}
+	if order.Processed {
+		return nil, fmt.Errorf("order %d is processed and cannot be edited", numOrderID)
+	}
+	e := &OrderEditor{
+		ctx:              ctx,
+		orders:           o,
+		order:            order,
+		fulfilmentCenter: order.FulfilmentLocationID,
+		base:             make(map[strfmt.UUID]*models.OrderItem, len(order.Items)),
+		current:          make(map[strfmt.UUID]*models.OrderItem, len(order.Items)),
+		err:              make([]error, 0),
+	}
+	if e.fulfilmentCenter == "" && order.GeneralInfo != nil {
+		e.fulfilmentCenter = order.GeneralInfo.Location
+	}
+	for _, item := range order.Items {
+		if item == nil || item.RowID == "" {
+			continue
+		}
+		current, err := cloneJSON(item)
+		if err != nil {
+			return nil, fmt.Errorf("copy row %s: %w", item.RowID, err)
+		}
+		e.rows = append(e.rows, item.RowID)
+		e.base[item.RowID] = item
+		e.current[item.RowID] = current
+	}
+	return e, nil
+}
+
+// Order returns the order as loaded.
+func (e *OrderEditor) Order() *models.OrderDetails {
+	if e == nil {
+		return nil
+	}
+	return e.order
+}
+
+// Items returns copies of the current lines, without the ones pending in Add.
+func (e *OrderEditor) Items() ([]*models.OrderItem, error) {
+	if e == nil {
+		return nil, errors.New("editor is nil")
+	}
+	out := make([]*models.OrderItem, 0, len(e.current))
+	for _, row := range e.rows {
+		item, ok := e.current[row]
+		if !ok {
+			continue
+		}
+		c, err := cloneJSON(item)
+		if err != nil {
+			return nil, fmt.Errorf("copy row %s: %w", row, err)
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// FulfilmentCenter overrides the location taken from the loaded order.
+func (e *OrderEditor) FulfilmentCenter(location strfmt.UUID) *OrderEditor {
+	if e == nil {
+		return nil
+	}
+	if !strfmt.IsUUID(location.String()) {
+		e.err = append(e.err, fmt.Errorf("fulfilmentCenter %q is not a valid uuid", location))
+		return e
+	}
+	e.fulfilmentCenter = location
+	return e
+}
+
+// SetQuantity changes the quantity of a line; zero removes it.
+func (e *OrderEditor) SetQuantity(rowID strfmt.UUID, qty int32) *OrderEditor {
+	if e == nil {
+		return nil
+	}
+	if qty < 0 {
+		e.err = append(e.err, fmt.Errorf("row %s: quantity must not be negative", rowID))
+		return e
+	}
+	if qty == 0 {
+		return e.Remove(rowID)
+	}
+	return e.UpdateItem(rowID, func(item *models.OrderItem) { item.Quantity = qty })
+}
+
+func (e *OrderEditor) SetPrice(rowID strfmt.UUID, pricePerUnit float64) *OrderEditor {
+	if e == nil {
+		return nil
+	}
+	if pricePerUnit < 0 {
+		e.err = append(e.err, fmt.Errorf("row %s: pricePerUnit must not be negative", rowID))
+		return e
+	}
+	return e.UpdateItem(rowID, func(item *models.OrderItem) { item.PricePerUnit = pricePerUnit })
+}
+
+// SetDiscount sets the line discount in percent.
+func (e *OrderEditor) SetDiscount(rowID strfmt.UUID, pct float64) *OrderEditor {
+	if e == nil {
+		return nil
+	}
+	if pct < 0 || pct > 100 {
+		e.err = append(e.err, fmt.Errorf("row %s: discount must be between 0 and 100", rowID))
+		return e
+	}
+	return e.UpdateItem(rowID, func(item *models.OrderItem) { item.Discount = pct })
+}
+
+func (e *OrderEditor) SetTaxRate(rowID strfmt.UUID, pct float64) *OrderEditor {
+	if e == nil {
+		return nil
+	}
+	if pct < 0 || pct > 100 {
+		e.err = append(e.err, fmt.Errorf("row %s: taxRate must be between 0 and 100", rowID))
+		return e
+	}
+	return e.UpdateItem(rowID, func(item *models.OrderItem) { item.TaxRate = pct })
+}
+
+// UpdateItem lets fn change any field of the line. RowID cannot be changed.
+func (e *OrderEditor) UpdateItem(rowID strfmt.UUID, fn func(item *models.OrderItem)) *OrderEditor {
+	if e == nil {
+		return nil
+	}
+	item, ok := e.current[rowID]
+	if !ok {
+		e.err = append(e.err, fmt.Errorf("row %s is not on the order", rowID))
+		return e
+	}
+	fn(item)
+	item.RowID = rowID
+	return e
+}
+
+func (e *OrderEditor) Remove(rowID strfmt.UUID) *OrderEditor {
+	if e == nil {
+		return nil
+	}
+	if _, ok := e.current[rowID]; !ok {
+		e.err = append(e.err, fmt.Errorf("row %s is not on the order", rowID))
+		return e
+	}
+	delete(e.current, rowID)
+	return e
+}
+
+func (e *OrderEditor) Add(item NewOrderItem) *OrderEditor {
+	if e == nil {
+		return nil
+	}
+	if !strfmt.IsUUID(item.ItemID.String()) {
+		e.err = append(e.err, fmt.Errorf("itemId %q is not a valid uuid", item.ItemID))
+		return e
+	}
+	if item.Quantity <= 0 {
+		e.err = append(e.err, fmt.Errorf("item %s: quantity must be greater than 0", item.ItemID))
+		return e
+	}
+	e.added = append(e.added, item)
+	return e
+}
+
+// RetryPolicy overrides the client retry policy for every call of Save.
+func (e *OrderEditor) RetryPolicy(policy lw_api.RetryPolicy) *OrderEditor {
+	return e.WithOptions(lw_api.CallRetryPolicy(policy))
+}
+
+// WithOptions applies per-call options to every call of Save.
+func (e *OrderEditor) WithOptions(opts ...lw_api.CallOption) *OrderEditor {
+	if e == nil {
+		return nil
+	}
+	e.opts = append(e.opts, opts...)
+	return e
+}
+
+// Plan diffs the edited lines against the loaded ones without sending anything.
+func (e *OrderEditor) Plan() (*OrderEditPlan, error) {
+	if e == nil {
+		return nil, errors.New("editor is nil")
+	}
+	errs := make([]error, len(e.err))
+	copy(errs, e.err)
+	plan := &OrderEditPlan{Add: append([]NewOrderItem(nil), e.added...)}
+	for _, row := range e.rows {
+		item, ok := e.current[row]
+		if !ok {
+			plan.Remove = append(plan.Remove, row)
+			continue
+		}
+		diff, err := changed(e.base[row], item)
+		if err == nil && diff {
+			item, err = cloneJSON(item)
+			plan.Update = append(plan.Update, item)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("row %s: %w", row, err))
+		}
+	}
+	if !plan.Empty() && e.fulfilmentCenter == "" {
+		errs = append(errs, errors.New("fulfilmentCenter is required: the order has no location"))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return plan, nil
+}
+
+// Save sends the plan: removals first to free stock, then updates, then additions. It stops at
+// the first failed call and returns what was applied so far; a later Save sends only the rest.
+func (e *OrderEditor) Save() (*OrderEditResult, error) {
+	plan, err := e.Plan()
+	if err != nil {
+		return nil, err
+	}
+	res := &OrderEditResult{}
+	for _, row := range plan.Remove {
+		out, err := e.orders.RemoveOrderItem(e.ctx).
+			OrderID(e.order.OrderID).
+			Rowid(row).
+			FulfilmentCenter(e.fulfilmentCenter).
+			WithOptions(e.opts...).
+			Do()
+		if err != nil {
+			return res, fmt.Errorf("remove row %s: %w", row, err)
+		}
+		delete(e.base, row)
+		e.rows = without(e.rows, row)
+		res.Removed = append(res.Removed, out)
+		res.track(out.TotalsInfo)
+	}
+	for _, item := range plan.Update {
+		req := e.orders.UpdateOrderItem(e.ctx).
+			OrderID(e.order.OrderID).
+			FulfilmentCenter(e.fulfilmentCenter).
+			OrderItem(item).
+			WithOptions(e.opts...)
+		if gi := e.order.GeneralInfo; gi != nil {
+			req.Source(gi.Source, gi.SubSource)
+		}
+		out, err := req.Do()
+		if err != nil {
+			return res, fmt.Errorf("update row %s: %w", item.RowID, err)
+		}
+		// item is the plan's own copy
+		e.base[item.RowID] = item
+		res.Updated = append(res.Updated, out)
+		res.track(out.TotalsInfo)
+	}
+	for len(e.added) > 0 {
+		item := e.added[0]
+		req := e.orders.AddOrderItem(e.ctx).
+			OrderID(e.order.OrderID).
+			ItemID(item.ItemID).
+			ChannelSKU(item.ChannelSKU).
+			Quantity(item.Quantity).
+			FulfilmentCenter(e.fulfilmentCenter).
+			WithOptions(e.opts...)
+		if p := item.Pricing; p != nil {
+			req.PricePerUnit(p.PricePerUnit).
+				DiscountPercentage(p.DiscountPercentage).
+				TaxRatePercentage(p.TaxRatePercentage).
+				TaxInclusive(p.TaxInclusive)
+		}
+		out, err := req.Do()
+		if err != nil {
+			return res, fmt.Errorf("add item %s: %w", item.ItemID, err)
+		}
+		e.added = e.added[1:]
+		if out.Item != nil && out.Item.RowID != "" {
+			current, err := cloneJSON(out.Item)
+			if err != nil {
+				return res, fmt.Errorf("copy row %s: %w", out.Item.RowID, err)
+			}
+			e.rows = append(e.rows, out.Item.RowID)
+			e.base[out.Item.RowID] = out.Item
+			e.current[out.Item.RowID] = current
+		}
+		res.Added = append(res.Added, out)
+		res.track(out.TotalsInfo)
+	}
+	if res.Totals != nil {
+		e.order.TotalsInfo = res.Totals
+	}
+	return res, nil
+}
+
+func (r *OrderEditResult) track(totals *models.OrderTotalsInfo) {
+	if totals != nil {
+		r.Totals = totals
+	}
+}
+
+func without(rows []strfmt.UUID, row strfmt.UUID) []strfmt.UUID {
+	out := rows[:0]
+	for _, r := range rows {
+		if r != row {
+			out = append(out, r)
+		}
+	}
+	return out
+}
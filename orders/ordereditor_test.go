@@ -0,0 +1,182 @@
+package orders_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/MMC-BK/lw-api/lwmock"
+	"github.com/MMC-BK/lw-api/orders"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+func editable(m *lwmock.API) (rows []strfmt.UUID) {
+	rows = orderIDs(3)
+	m.OnGetOrderDetailsByNumOrderId(func(orderID int32) (*models.OrderDetails, error) {
+		return &models.OrderDetails{
+			OrderID:              "00000000-0000-4000-8000-000000000100",
+			NumOrderID:           orderID,
+			FulfilmentLocationID: "00000000-0000-0000-0000-000000000000",
+			GeneralInfo:          &models.OrderGeneralInfo{Source: "MYSHOP", SubSource: "myshop.example"},
+			Items: []*models.OrderItem{
+				{RowID: rows[0], SKU: "A", Quantity: 1, PricePerUnit: 10},
+				{RowID: rows[1], SKU: "B", Quantity: 2, PricePerUnit: 5},
+				{RowID: rows[2], SKU: "C", Quantity: 1, PricePerUnit: 7},
+			},
+		}, nil
+	})
+	return rows
+}
+
+func TestOrderEditor(t *testing.T) {
+	ctx := context.Background()
+	stock := strfmt.UUID("00000000-0000-4000-8000-000000000200")
+
+	t.Run("should plan only the changed lines", func(t *testing.T) {
+		m := lwmock.New()
+		rows := editable(m)
+		ed, err := m.EditOrder(ctx, 1001)
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		plan, err := ed.
+			SetQuantity(rows[0], 1).
+			SetPrice(rows[1], 6).
+			SetQuantity(rows[2], 0).
+			Add(orders.NewOrderItem{ItemID: stock, Quantity: 1}).
+			Plan()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if !slices.Equal(plan.Remove, []strfmt.UUID{rows[2]}) {
+			t.Errorf("unexpected removals %v", plan.Remove)
+		}
+		if len(plan.Update) != 1 || plan.Update[0].RowID != rows[1] || plan.Update[0].PricePerUnit != 6 {
+			t.Errorf("unexpected updates %+v", plan.Update)
+		}
+		if len(plan.Add) != 1 {
+			t.Errorf("unexpected additions %+v", plan.Add)
+		}
+	})
+
+	t.Run("should save in order and return the last totals", func(t *testing.T) {
+		m := lwmock.New()
+		rows := editable(m)
+		m.OnRemoveOrderItem(func(req *models.OrdersRemoveOrderItemRequest) (*models.UpdateTotalsResult, error) {
+			return &models.UpdateTotalsResult{TotalsInfo: &models.OrderTotalsInfo{TotalCharge: 20}}, nil
+		})
+		m.OnUpdateOrderItem(func(req *models.OrdersUpdateOrderItemRequest) (*models.UpdateTotalsResult, error) {
+			if req.Source != "MYSHOP" || req.OrderItem.Quantity != 4 {
+				t.Errorf("unexpected update %+v", req)
+			}
+			return &models.UpdateTotalsResult{TotalsInfo: &models.OrderTotalsInfo{TotalCharge: 30}}, nil
+		})
+		m.OnAddOrderItem(func(req *models.OrdersAddOrderItemRequest) (*models.UpdateOrderItemResult, error) {
+			if req.LinePricing == nil || req.LinePricing.PricePerUnit != 3 {
+				t.Errorf("unexpected pricing %+v", req.LinePricing)
+			}
+			return &models.UpdateOrderItemResult{
+				Item:       &models.OrderItem{RowID: "00000000-0000-4000-8000-000000000300", ItemID: req.ItemID, Quantity: req.Quantity},
+				TotalsInfo: &models.OrderTotalsInfo{TotalCharge: 33},
+			}, nil
+		})
+		ed, err := m.EditOrder(ctx, 1001)
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		res, err := ed.
+			Remove(rows[0]).
+			SetQuantity(rows[1], 4).
+			Add(orders.NewOrderItem{ItemID: stock, Quantity: 1, Pricing: &models.LinePricingRequest{PricePerUnit: 3}}).
+			Save()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		var order []string
+		for _, c := range m.Calls("") {
+			order = append(order, c.Path)
+		}
+		want := []string{"/api/Orders/GetOrderDetailsByNumOrderId", "/api/Orders/RemoveOrderItem", "/api/Orders/UpdateOrderItem", "/api/Orders/AddOrderItem"}
+		if !slices.Equal(order, want) {
+			t.Errorf("Expected %v, got %v", want, order)
+		}
+		if res.Totals == nil || res.Totals.TotalCharge != 33 || len(res.Added) != 1 || len(res.Updated) != 1 || len(res.Removed) != 1 {
+			t.Errorf("unexpected result %+v", res)
+		}
+		if items, err := ed.Items(); err != nil || len(items) != 3 {
+			t.Errorf("Expected 3 lines after save, got %d, %v", len(items), err)
+		}
+		plan, err := ed.Plan()
+		if err != nil || !plan.Empty() {
+			t.Errorf("Expected empty plan after save, got %+v, %v", plan, err)
+		}
+	})
+
+	t.Run("should resume after a failed call", func(t *testing.T) {
+		m := lwmock.New()
+		rows := editable(m)
+		fail := true
+		m.OnUpdateOrderItem(func(req *models.OrdersUpdateOrderItemRequest) (*models.UpdateTotalsResult, error) {
+			if fail && req.OrderItem.RowID == rows[1] {
+				return nil, errors.New("boom")
+			}
+			return &models.UpdateTotalsResult{}, nil
+		})
+		ed, err := m.EditOrder(ctx, 1001)
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		res, err := ed.SetDiscount(rows[0], 10).SetDiscount(rows[1], 10).Save()
+		if err == nil || len(res.Updated) != 1 {
+			t.Fatalf("Expected partial result and error, got %+v, %v", res, err)
+		}
+		fail = false
+		res, err = ed.Save()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if len(res.Updated) != 1 {
+			t.Errorf("Expected only the failed line to be resent, got %d updates", len(res.Updated))
+		}
+	})
+
+	t.Run("should collect edit errors", func(t *testing.T) {
+		m := lwmock.New()
+		rows := editable(m)
+		ed, err := m.EditOrder(ctx, 1001)
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		_, err = ed.
+			SetQuantity("00000000-0000-4000-8000-000000000999", 1).
+			SetDiscount(rows[0], 120).
+			Add(orders.NewOrderItem{ItemID: stock}).
+			Save()
+		if err == nil {
+			t.Fatal("Expected error")
+		}
+		if len(m.Calls("")) != 1 {
+			t.Error("Expected no edit calls")
+		}
+	})
+	t.Run("should report values JSON cannot carry", func(t *testing.T) {
+		m := lwmock.New()
+		rows := editable(m)
+		ed, err := m.EditOrder(ctx, 1001)
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		ed.SetPrice(rows[0], math.NaN())
+		if _, err := ed.Plan(); err == nil || !strings.Contains(err.Error(), string(rows[0])) {
+			t.Errorf("Expected error for row %s, got %v", rows[0], err)
+		}
+		if _, err := ed.Items(); err == nil {
+			t.Error("Expected Items error, got nil")
+		}
+	})
+}
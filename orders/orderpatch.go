@@ -0,0 +1,474 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+
+	lw_api "github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+// OrderPatchPlan holds the calls PatchOrder sends; nil and empty members are not sent.
+type OrderPatchPlan struct {
+	// CustomerInfo is set when the delivery address or buyer name changed; it carries the billing
+	// address too. BillingAddress is set instead when only the billing address changed.
+	CustomerInfo   *models.OrderCustomerInfo
+	BillingAddress *models.CustomerAddress
+	GeneralInfo    *models.OrderGeneralInfo
+	ShippingInfo   *models.UpdateOrderShippingInfoRequest
+	TotalsInfo     *models.OrderTotalsInfo
+	ShippingMethod string
+}
+
+// Empty reports whether the patch changes nothing.
+func (p *OrderPatchPlan) Empty() bool {
+	return p == nil || p.CustomerInfo == nil && p.BillingAddress == nil && p.GeneralInfo == nil &&
+		p.ShippingInfo == nil && p.TotalsInfo == nil && p.ShippingMethod == ""
+}
+
+// OrderPatchResult is the applied plan and the totals Linnworks recalculated for a shipping change.
+type OrderPatchResult struct {
+	Plan *OrderPatchPlan
+	// Shipping is the SetOrderShippingInfo response; nil when shipping did not change.
+	Shipping *models.UpdateTotalsResult
+}
+
+// OrderPatchBuilder changes single header fields of an order. The Linnworks setters replace
+// customer, general, shipping and totals info as a whole, so the builder loads the order,
+// applies the patch to a copy and sends only the sections that differ, with every other field
+// as Linnworks has it.
+//
+//	api.PatchOrder(ctx, id).
+//		DeliveryAddress(orders.NewAddressPatch().Address2("Flat 3").CountryCode("GB")).
+//		TrackingNumber("JD0002").
+//		Do()
+type OrderPatchBuilder struct {
+	ctx            context.Context
+	orders         Orders
+	orderID        strfmt.UUID
+	delivery       *AddressPatch
+	billing        *AddressPatch
+	buyerName      *string
+	saveToCrm      bool
+	general        []func(*models.OrderGeneralInfo)
+	shipping       []func(*models.UpdateOrderShippingInfoRequest)
+	totals         []func(*models.OrderTotalsInfo)
+	shippingMethod string
+	// postalService is set by PostalServiceID, which conflicts with ShippingMethod
+	postalService bool
+	err           []error
+	opts          []lw_api.CallOption
+}
+
+func (o Orders) PatchOrder(ctx context.Context, orderID strfmt.UUID) *OrderPatchBuilder {
+	b := &OrderPatchBuilder{
+		ctx:    ctx,
+		orders: o,
+		err:    make([]error, 0),
+	}
+	if !strfmt.IsUUID(orderID.String()) {
+		b.err = append(b.err, fmt.Errorf("orderId %q is not a valid uuid", orderID))
+		return b
+	}
+	b.orderID = orderID
+	return b
+}
+
+func (b *OrderPatchBuilder) DeliveryAddress(patch *AddressPatch) *OrderPatchBuilder {
+	if b == nil {
+		return nil
+	}
+	if patch == nil {
+		b.err = append(b.err, errors.New("deliveryAddress cannot be nil"))
+		return b
+	}
+	b.delivery = patch
+	return b
+}
+
+func (b *OrderPatchBuilder) BillingAddress(patch *AddressPatch) *OrderPatchBuilder {
+	if b == nil {
+		return nil
+	}
+	if patch == nil {
+		b.err = append(b.err, errors.New("billingAddress cannot be nil"))
+		return b
+	}
+	b.billing = patch
+	return b
+}
+
+func (b *OrderPatchBuilder) ChannelBuyerName(name string) *OrderPatchBuilder {
+	if b == nil {
+		return nil
+	}
+	b.buyerName = &name
+	return b
+}
+
+// SaveToCrm also stores changed customer details in the Linnworks CRM.
+func (b *OrderPatchBuilder) SaveToCrm(value bool) *OrderPatchBuilder {
+	if b == nil {
+		return nil
+	}
+	b.saveToCrm = value
+	return b
+}
+
+func (b *OrderPatchBuilder) ReferenceNum(value string) *OrderPatchBuilder {
+	return b.GeneralInfo(func(info *models.OrderGeneralInfo) { info.ReferenceNum = value })
+}
+
+func (b *OrderPatchBuilder) SecondaryReference(value string) *OrderPatchBuilder {
+	return b.GeneralInfo(func(info *models.OrderGeneralInfo) { info.SecondaryReference = value })
+}
+
+func (b *OrderPatchBuilder) ExternalReferenceNum(value string) *OrderPatchBuilder {
+	return b.GeneralInfo(func(info *models.OrderGeneralInfo) { info.ExternalReferenceNum = value })
+}
+
+func (b *OrderPatchBuilder) DespatchByDate(t time.Time) *OrderPatchBuilder {
+	if b == nil {
+		return nil
+	}
+	if t.IsZero() {
+		b.err = append(b.err, errors.New("despatchByDate must not be zero"))
+		return b
+	}
+	return b.GeneralInfo(func(info *models.OrderGeneralInfo) { info.DespatchByDate = strfmt.DateTime(t) })
+}
+
+// GeneralInfo changes any other general info field on the loaded copy.
+func (b *OrderPatchBuilder) GeneralInfo(fn func(info *models.OrderGeneralInfo)) *OrderPatchBuilder {
+	if b == nil {
+		return nil
+	}
+	b.general = append(b.general, fn)
+	return b
+}
+
+func (b *OrderPatchBuilder) PostalServiceID(id strfmt.UUID) *OrderPatchBuilder {
+	if b == nil {
+		return nil
+	}
+	if !strfmt.IsUUID(id.String()) {
+		b.err = append(b.err, fmt.Errorf("postalServiceId %q is not a valid uuid", id))
+		return b
+	}
+	b.postalService = true
+	return b.shippingInfo(func(info *models.UpdateOrderShippingInfoRequest) { info.PostalServiceID = id })
+}
+
+// PostageCost also marks the postage as manually adjusted, so rules do not recalculate it.
+func (b *OrderPatchBuilder) PostageCost(cost float64) *OrderPatchBuilder {
+	if b == nil {
+		return nil
+	}
+	if cost < 0 {
+		b.err = append(b.err, errors.New("postageCost must not be negative"))
+		return b
+	}
+	return b.shippingInfo(func(info *models.UpdateOrderShippingInfoRequest) {
+		info.PostageCost = cost
+		info.ManualAdjust = true
+	})
+}
+
+func (b *OrderPatchBuilder) TrackingNumber(value string) *OrderPatchBuilder {
+	return b.shippingInfo(func(info *models.UpdateOrderShippingInfoRequest) { info.TrackingNumber = value })
+}
+
+func (b *OrderPatchBuilder) TotalWeight(value float64) *OrderPatchBuilder {
+	if b == nil {
+		return nil
+	}
+	if value < 0 {
+		b.err = append(b.err, errors.New("totalWeight must not be negative"))
+		return b
+	}
+	return b.shippingInfo(func(info *models.UpdateOrderShippingInfoRequest) { info.TotalWeight = value })
+}
+
+func (b *OrderPatchBuilder) shippingInfo(fn func(*models.UpdateOrderShippingInfoRequest)) *OrderPatchBuilder {
+	if b == nil {
+		return nil
+	}
+	b.shipping = append(b.shipping, fn)
+	return b
+}
+
+// TotalsInfo changes totals fields such as Currency, PaymentMethod or TotalDiscount on the loaded copy.
+func (b *OrderPatchBuilder) TotalsInfo(fn func(info *models.OrderTotalsInfo)) *OrderPatchBuilder {
+	if b == nil {
+		return nil
+	}
+	b.totals = append(b.totals, fn)
+	return b
+}
+
+// ShippingMethod moves the order to another postal service by name, see ChangeShippingMethod.
+func (b *OrderPatchBuilder) ShippingMethod(name string) *OrderPatchBuilder {
+	if b == nil {
+		return nil
+	}
+	if name == "" {
+		b.err = append(b.err, errors.New("shippingMethod cannot be empty"))
+		return b
+	}
+	b.shippingMethod = name
+	return b
+}
+
+// RetryPolicy overrides the client retry policy for every call of the patch.
+func (b *OrderPatchBuilder) RetryPolicy(policy lw_api.RetryPolicy) *OrderPatchBuilder {
+	return b.WithOptions(lw_api.CallRetryPolicy(policy))
+}
+
+// WithOptions applies per-call options to every call of the patch.
+func (b *OrderPatchBuilder) WithOptions(opts ...lw_api.CallOption) *OrderPatchBuilder {
+	if b == nil {
+		return nil
+	}
+	b.opts = append(b.opts, opts...)
+	return b
+}
+
+// Plan loads the order and works out the calls without sending them.
+func (b *OrderPatchBuilder) Plan() (*OrderPatchPlan, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	errs := make([]error, len(b.err))
+	copy(errs, b.err)
+	if b.shippingMethod != "" && b.postalService {
+		errs = append(errs, errors.New("shippingMethod and postalServiceId both choose the postal service: set one of them"))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	found, err := b.orders.GetOrdersById(b.ctx).PkOrderIds([]strfmt.UUID{b.orderID}).WithOptions(b.opts...).Do()
+	if err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("order %s not found", b.orderID)
+	}
+	order := &found[0]
+	if order.Processed {
+		return nil, fmt.Errorf("order %s is processed and cannot be changed", b.orderID)
+	}
+
+	var countries []models.OrderCountry
+	if b.delivery.needsCountries() || b.billing.needsCountries() {
+		if countries, err = b.orders.GetCountries(b.ctx).WithOptions(b.opts...).Do(); err != nil {
+			return nil, fmt.Errorf("load countries: %w", err)
+		}
+	}
+
+	plan := &OrderPatchPlan{}
+	customer, err := cloneJSON(order.CustomerInfo)
+	if err != nil {
+		return nil, fmt.Errorf("copy customer info: %w", err)
+	}
+	if customer == nil {
+		customer = &models.OrderCustomerInfo{}
+	}
+	var deliveryChanged, billingChanged bool
+	if b.delivery != nil {
+		var addrErrs []error
+		customer.Address, deliveryChanged, addrErrs = patchAddress(customer.Address, b.delivery, countries)
+		errs = append(errs, nest("$.DeliveryAddress", addrErrs)...)
+	}
+	if b.billing != nil {
+		var addrErrs []error
+		customer.BillingAddress, billingChanged, addrErrs = patchAddress(customer.BillingAddress, b.billing, countries)
+		errs = append(errs, nest("$.BillingAddress", addrErrs)...)
+	}
+	buyerChanged := b.buyerName != nil && *b.buyerName != customer.ChannelBuyerName
+	if buyerChanged {
+		customer.ChannelBuyerName = *b.buyerName
+	}
+	switch {
+	case deliveryChanged || buyerChanged:
+		if customer.Address == nil {
+			errs = append(errs, fieldError("$.DeliveryAddress", "required", nil, "the order has no delivery address to keep"))
+		}
+		plan.CustomerInfo = customer
+	case billingChanged:
+		plan.BillingAddress = customer.BillingAddress
+	}
+
+	if len(b.general) > 0 {
+		general, err := cloneJSON(order.GeneralInfo)
+		if err != nil {
+			return nil, fmt.Errorf("copy general info: %w", err)
+		}
+		if general == nil {
+			general = &models.OrderGeneralInfo{}
+		}
+		for _, fn := range b.general {
+			fn(general)
+		}
+		diff, err := changed(order.GeneralInfo, general)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("general info: %w", err))
+		} else if diff {
+			plan.GeneralInfo = general
+		}
+	}
+
+	if len(b.shipping) > 0 {
+		shipping, diff, err := b.patchShipping(order.ShippingInfo)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("shipping info: %w", err))
+		} else if diff {
+			plan.ShippingInfo = shipping
+		}
+	}
+
+	if len(b.totals) > 0 {
+		totals, err := cloneJSON(order.TotalsInfo)
+		if err != nil {
+			return nil, fmt.Errorf("copy totals info: %w", err)
+		}
+		if totals == nil {
+			totals = &models.OrderTotalsInfo{}
+		}
+		for _, fn := range b.totals {
+			fn(totals)
+		}
+		if totals.Currency != "" && !isUpperAlpha(totals.Currency, 3) {
+			errs = append(errs, fieldError("$.TotalsInfo.Currency", "pattern", totals.Currency, "must be a 3 letter ISO 4217 code"))
+		}
+		diff, err := changed(order.TotalsInfo, totals)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("totals info: %w", err))
+		} else if diff {
+			plan.TotalsInfo = totals
+		}
+	}
+
+	if b.shippingMethod != "" && (order.ShippingInfo == nil || order.ShippingInfo.PostalServiceName != b.shippingMethod) {
+		plan.ShippingMethod = b.shippingMethod
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return plan, nil
+}
+
+// Do sends the changed sections. The shipping method goes first because it resets postage,
+// which a shipping info change in the same patch then overrides; that change is rebased on the
+// reloaded shipping info so it keeps the new PostalServiceId.
+func (b *OrderPatchBuilder) Do() (*OrderPatchResult, error) {
+	plan, err := b.Plan()
+	if err != nil {
+		return nil, err
+	}
+	res := &OrderPatchResult{Plan: plan}
+	if plan.ShippingMethod != "" {
+		if err := b.orders.ChangeShippingMethod(b.ctx).OrderIds([]strfmt.UUID{b.orderID}).ShippingMethod(plan.ShippingMethod).WithOptions(b.opts...).Do(); err != nil {
+			return res, fmt.Errorf("change shipping method: %w", err)
+		}
+		if plan.ShippingInfo != nil {
+			// the planned info still holds the old PostalServiceId and would switch the service back
+			if plan.ShippingInfo, err = b.reloadShipping(); err != nil {
+				return res, fmt.Errorf("reload shipping info: %w", err)
+			}
+		}
+	}
+	if plan.CustomerInfo != nil {
+		if err := b.orders.SetOrderCustomerInfo(b.ctx).OrderID(b.orderID).Info(plan.CustomerInfo).SaveToCrm(b.saveToCrm).WithOptions(b.opts...).Do(); err != nil {
+			return res, fmt.Errorf("set customer info: %w", err)
+		}
+	}
+	if plan.BillingAddress != nil {
+		if err := b.orders.UpdateBillingAddress(b.ctx).OrderID(b.orderID).BillingAddress(plan.BillingAddress).WithOptions(b.opts...).Do(); err != nil {
+			return res, fmt.Errorf("update billing address: %w", err)
+		}
+	}
+	if plan.GeneralInfo != nil {
+		if err := b.orders.SetOrderGeneralInfo(b.ctx).OrderID(b.orderID).Info(plan.GeneralInfo).WithOptions(b.opts...).Do(); err != nil {
+			return res, fmt.Errorf("set general info: %w", err)
+		}
+	}
+	if plan.ShippingInfo != nil {
+		out, err := b.orders.SetOrderShippingInfo(b.ctx).OrderID(b.orderID).Info(plan.ShippingInfo).WithOptions(b.opts...).Do()
+		if err != nil {
+			return res, fmt.Errorf("set shipping info: %w", err)
+		}
+		res.Shipping = out
+	}
+	if plan.TotalsInfo != nil {
+		if err := b.orders.SetOrderTotalsInfo(b.ctx).OrderID(b.orderID).Info(plan.TotalsInfo).WithOptions(b.opts...).Do(); err != nil {
+			return res, fmt.Errorf("set totals info: %w", err)
+		}
+	}
+	return res, nil
+}
+
+// patchShipping applies the shipping changes to a copy of info.
+func (b *OrderPatchBuilder) patchShipping(info *models.OrderShippingInfo) (*models.UpdateOrderShippingInfoRequest, bool, error) {
+	current := shippingRequest(info)
+	shipping, err := cloneJSON(current)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, fn := range b.shipping {
+		fn(shipping)
+	}
+	diff, err := changed(current, shipping)
+	if err != nil {
+		return nil, false, err
+	}
+	return shipping, diff, nil
+}
+
+// reloadShipping applies the shipping changes to the shipping info Linnworks has now.
+func (b *OrderPatchBuilder) reloadShipping() (*models.UpdateOrderShippingInfoRequest, error) {
+	found, err := b.orders.GetOrdersById(b.ctx).PkOrderIds([]strfmt.UUID{b.orderID}).WithOptions(b.opts...).Do()
+	if err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("order %s not found", b.orderID)
+	}
+	shipping, _, err := b.patchShipping(found[0].ShippingInfo)
+	return shipping, err
+}
+
+// patchAddress applies the patch to a copy of current and validates the result.
+func patchAddress(current *models.CustomerAddress, patch *AddressPatch, countries []models.OrderCountry) (*models.CustomerAddress, bool, []error) {
+	out, err := cloneJSON(current)
+	if err != nil {
+		return nil, false, []error{err}
+	}
+	if out == nil {
+		out = &models.CustomerAddress{}
+	}
+	errs := patch.apply(out, countries)
+	errs = append(errs, validateAddress(out)...)
+	diff, err := changed(current, out)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return out, diff, errs
+}
+
+func shippingRequest(info *models.OrderShippingInfo) *models.UpdateOrderShippingInfoRequest {
+	if info == nil {
+		return &models.UpdateOrderShippingInfoRequest{}
+	}
+	return &models.UpdateOrderShippingInfoRequest{
+		PostalServiceID: info.PostalServiceID,
+		PostageCost:     info.PostageCost,
+		ManualAdjust:    info.ManualAdjust,
+		ItemWeight:      info.ItemWeight,
+		TotalWeight:     info.TotalWeight,
+		TrackingNumber:  info.TrackingNumber,
+	}
+}
@@ -0,0 +1,226 @@
+package orders_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/lwmock"
+	"github.com/MMC-BK/lw-api/orders"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+func patchable(m *lwmock.API) strfmt.UUID {
+	id := strfmt.UUID("00000000-0000-4000-8000-000000000100")
+	m.OnGetOrdersById(func(req *models.OrdersGetOrdersByIDRequest) ([]models.OrderDetails, error) {
+		return []models.OrderDetails{{
+			OrderID:    id,
+			NumOrderID: 1001,
+			GeneralInfo: &models.OrderGeneralInfo{
+				ReferenceNum: "A-1",
+				Source:       "MYSHOP",
+			},
+			CustomerInfo: &models.OrderCustomerInfo{
+				ChannelBuyerName: "jdoe",
+				Address:          &models.CustomerAddress{FullName: "J Doe", Address1: "1 High St", Town: "Leeds", Country: "United Kingdom"},
+				BillingAddress:   &models.CustomerAddress{FullName: "J Doe", Address1: "1 High St", Country: "United Kingdom"},
+			},
+			ShippingInfo: &models.OrderShippingInfo{PostalServiceName: "Standard", PostageCost: 3, TrackingNumber: "JD0001"},
+			TotalsInfo:   &models.OrderTotalsInfo{Currency: "GBP", Subtotal: 10, TotalCharge: 13},
+		}}, nil
+	})
+	m.OnGetCountries(func() ([]models.OrderCountry, error) {
+		return []models.OrderCountry{
+			{CountryName: "United Kingdom", CountryCode: "GB", CountryID: "00000000-0000-4000-8000-000000000001", Continent: "Europe"},
+			{CountryName: "Ireland", CountryCode: "IE", CountryID: "00000000-0000-4000-8000-000000000002", Continent: "Europe"},
+		}, nil
+	})
+	return id
+}
+
+func TestPatchOrder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should send only the changed sections", func(t *testing.T) {
+		m := lwmock.New()
+		id := patchable(m)
+		m.OnSetOrderGeneralInfo(func(req *models.OrdersSetOrderGeneralInfoRequest) error {
+			if req.Info.ReferenceNum != "A-1" || req.Info.SecondaryReference != "PO-7" || req.Info.Source != "MYSHOP" {
+				t.Errorf("unexpected general info %+v", req.Info)
+			}
+			return nil
+		})
+		m.OnSetOrderShippingInfo(func(req *models.OrdersSetOrderShippingInfoRequest) (*models.UpdateTotalsResult, error) {
+			if req.Info.TrackingNumber != "JD0002" || req.Info.PostageCost != 3 {
+				t.Errorf("unexpected shipping info %+v", req.Info)
+			}
+			return &models.UpdateTotalsResult{TotalsInfo: &models.OrderTotalsInfo{TotalCharge: 13}}, nil
+		})
+		res, err := m.PatchOrder(ctx, id).
+			ReferenceNum("A-1").
+			SecondaryReference("PO-7").
+			TrackingNumber("JD0002").
+			ChannelBuyerName("jdoe").
+			ShippingMethod("Standard").
+			Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if res.Shipping == nil || res.Plan.CustomerInfo != nil || res.Plan.TotalsInfo != nil || res.Plan.ShippingMethod != "" {
+			t.Errorf("unexpected result %+v", res.Plan)
+		}
+		for _, path := range []string{"/api/Orders/SetOrderCustomerInfo", "/api/Orders/UpdateBillingAddress", "/api/Orders/SetOrderTotalsInfo", "/api/Orders/ChangeShippingMethod", "/api/Orders/GetCountries"} {
+			if n := len(m.Calls(path)); n != 0 {
+				t.Errorf("Expected no calls to %s, got %d", path, n)
+			}
+		}
+	})
+
+	t.Run("should resolve country codes and keep the other address lines", func(t *testing.T) {
+		m := lwmock.New()
+		id := patchable(m)
+		m.OnSetOrderCustomerInfo(func(req *models.OrdersSetOrderCustomerInfoRequest) error {
+			a := req.Info.Address
+			if a.Address1 != "1 High St" || a.Address2 != "Flat 3" || a.Country != "Ireland" || a.CountryID != "00000000-0000-4000-8000-000000000002" {
+				t.Errorf("unexpected address %+v", a)
+			}
+			if req.Info.BillingAddress == nil || req.Info.ChannelBuyerName != "jdoe" {
+				t.Errorf("Expected the rest of the customer info to be kept, got %+v", req.Info)
+			}
+			return nil
+		})
+		if _, err := m.PatchOrder(ctx, id).DeliveryAddress(orders.NewAddressPatch().Address2("Flat 3").CountryCode("IE")).Do(); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if n := len(m.Calls("/api/Orders/SetOrderCustomerInfo")); n != 1 {
+			t.Errorf("Expected 1 customer info call, got %d", n)
+		}
+	})
+
+	t.Run("should use UpdateBillingAddress when only billing changed", func(t *testing.T) {
+		m := lwmock.New()
+		id := patchable(m)
+		m.OnUpdateBillingAddress(func(req *models.OrdersUpdateBillingAddressRequest) error {
+			if req.BillingAddress.Company != "Doe Ltd" || req.BillingAddress.Address1 != "1 High St" {
+				t.Errorf("unexpected billing address %+v", req.BillingAddress)
+			}
+			return nil
+		})
+		plan, err := m.PatchOrder(ctx, id).BillingAddress(orders.NewAddressPatch().Company("Doe Ltd")).Plan()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if plan.CustomerInfo != nil || plan.BillingAddress == nil {
+			t.Errorf("unexpected plan %+v", plan)
+		}
+		if _, err := m.PatchOrder(ctx, id).BillingAddress(orders.NewAddressPatch().Company("Doe Ltd")).Do(); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+	})
+
+	t.Run("should send nothing when values are unchanged", func(t *testing.T) {
+		m := lwmock.New()
+		id := patchable(m)
+		plan, err := m.PatchOrder(ctx, id).
+			DeliveryAddress(orders.NewAddressPatch().Town("Leeds").CountryCode("GB")).
+			PostageCost(3).
+			Plan()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if plan.ShippingInfo == nil {
+			t.Error("Expected PostageCost to set ManualAdjust and change shipping")
+		}
+		plan, err = m.PatchOrder(ctx, id).ReferenceNum("A-1").TrackingNumber("JD0001").Plan()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if !plan.Empty() {
+			t.Errorf("Expected empty plan, got %+v", plan)
+		}
+	})
+
+	t.Run("should reject unknown countries and half-filled addresses", func(t *testing.T) {
+		m := lwmock.New()
+		id := patchable(m)
+		_, err := m.PatchOrder(ctx, id).
+			DeliveryAddress(orders.NewAddressPatch().Address1("").CountryCode("ZZ")).
+			BillingAddress(orders.NewAddressPatch().FullName("").CountryCode("gb")).
+			Do()
+		var fe *client.FieldError
+		if !errors.As(err, &fe) {
+			t.Fatalf("Expected field error, got %v", err)
+		}
+		for _, want := range []string{"$.DeliveryAddress.Address1", "$.DeliveryAddress.CountryCode", "$.BillingAddress.CountryCode"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected error for %s, got %v", want, err)
+			}
+		}
+		if n := len(m.Calls("/api/Orders/SetOrderCustomerInfo")); n != 0 {
+			t.Errorf("Expected no calls, got %d", n)
+		}
+	})
+
+	t.Run("should keep the new postal service when postage changes with the method", func(t *testing.T) {
+		m := lwmock.New()
+		id := strfmt.UUID("00000000-0000-4000-8000-000000000100")
+		express := strfmt.UUID("00000000-0000-4000-8000-000000000302")
+		shipping := &models.OrderShippingInfo{PostalServiceName: "Standard", PostalServiceID: "00000000-0000-4000-8000-000000000301", PostageCost: 3}
+		m.OnGetOrdersById(func(req *models.OrdersGetOrdersByIDRequest) ([]models.OrderDetails, error) {
+			si := *shipping
+			return []models.OrderDetails{{OrderID: id, ShippingInfo: &si}}, nil
+		})
+		m.OnChangeShippingMethod(func(req *models.OrdersChangeShippingMethodRequest) error {
+			shipping.PostalServiceName, shipping.PostalServiceID, shipping.PostageCost = req.ShippingMethod, express, 6
+			return nil
+		})
+		m.OnSetOrderShippingInfo(func(req *models.OrdersSetOrderShippingInfoRequest) (*models.UpdateTotalsResult, error) {
+			if req.Info.PostalServiceID != express || req.Info.PostageCost != 4 {
+				t.Errorf("unexpected shipping info %+v", req.Info)
+			}
+			return &models.UpdateTotalsResult{}, nil
+		})
+		if _, err := m.PatchOrder(ctx, id).ShippingMethod("Express").PostageCost(4).Do(); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if n := len(m.Calls("/api/Orders/GetOrdersById")); n != 2 {
+			t.Errorf("Expected the order to be reloaded, got %d loads", n)
+		}
+	})
+
+	t.Run("should refuse a shipping method with a postal service id", func(t *testing.T) {
+		m := lwmock.New()
+		id := patchable(m)
+		_, err := m.PatchOrder(ctx, id).ShippingMethod("Express").PostalServiceID("00000000-0000-4000-8000-000000000302").Plan()
+		if err == nil || !strings.Contains(err.Error(), "set one of them") {
+			t.Errorf("Expected conflict error, got %v", err)
+		}
+		if n := len(m.Calls("")); n != 0 {
+			t.Errorf("Expected no calls, got %d", n)
+		}
+	})
+
+	t.Run("should report values JSON cannot carry", func(t *testing.T) {
+		m := lwmock.New()
+		id := patchable(m)
+		_, err := m.PatchOrder(ctx, id).PostageCost(math.NaN()).Plan()
+		if err == nil || !strings.Contains(err.Error(), "shipping info") {
+			t.Errorf("Expected shipping info error, got %v", err)
+		}
+	})
+
+	t.Run("should refuse processed orders", func(t *testing.T) {
+		m := lwmock.New()
+		m.OnGetOrdersById(func(req *models.OrdersGetOrdersByIDRequest) ([]models.OrderDetails, error) {
+			return []models.OrderDetails{{OrderID: req.PkOrderIds[0], Processed: true}}, nil
+		})
+		if _, err := m.PatchOrder(ctx, "00000000-0000-4000-8000-000000000100").TrackingNumber("X").Do(); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,11 @@
+package orders
+
+import "errors"
+
+func (b *ProcessFulfilmentCentreOrderRequestBuilder) requiredField() []error {
+	var errs []error
+	if b.data.OrderID == "" {
+		errs = append(errs, errors.New("orderId is required"))
+	}
+	return errs
+}
@@ -0,0 +1,260 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-openapi/strfmt"
+
+	lw_api "github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+// DefaultProcessBatchSize is how many orders ProcessMany sends per ProcessOrdersInBatch call.
+const DefaultProcessBatchSize = 100
+
+// ProcessOutcome is an order that was not processed and the reason.
+type ProcessOutcome struct {
+	OrderID strfmt.UUID
+	Error   string
+}
+
+// ProcessReport sorts the orders passed to ProcessMany by what happened to them.
+type ProcessReport struct {
+	Succeeded []strfmt.UUID
+	// Failed holds orders Linnworks refused, with its Error text, and orders of batches it rejected as a whole.
+	Failed []ProcessOutcome
+	// Skipped holds orders that were never sent or were turned away by the rate limit; they are safe to process again.
+	Skipped []ProcessOutcome
+	// Unknown holds orders of batches that were sent but got no usable answer (network error,
+	// 5xx, no result for the order). Check their state before sending them again.
+	Unknown []ProcessOutcome
+}
+
+// Err joins the failures, skips and unknown outcomes into one error; nil when every order was processed.
+func (r *ProcessReport) Err() error {
+	if r == nil {
+		return nil
+	}
+	var errs []error
+	for _, f := range r.Failed {
+		errs = append(errs, fmt.Errorf("order %s failed: %s", f.OrderID, f.Error))
+	}
+	for _, s := range r.Skipped {
+		errs = append(errs, fmt.Errorf("order %s skipped: %s", s.OrderID, s.Error))
+	}
+	for _, u := range r.Unknown {
+		errs = append(errs, fmt.Errorf("order %s outcome unknown: %s", u.OrderID, u.Error))
+	}
+	return errors.Join(errs...)
+}
+
+// ProcessManyBuilder processes any number of orders through ProcessOrdersInBatch and reports
+// the result of every order.
+type ProcessManyBuilder struct {
+	ctx           context.Context
+	orders        Orders
+	ids           []strfmt.UUID
+	location      strfmt.UUID
+	clientContext *models.ClientContext
+	batchSize     int
+	stopOnError   bool
+	err           []error
+	opts          []lw_api.CallOption
+}
+
+func (o Orders) ProcessMany(ctx context.Context) *ProcessManyBuilder {
+	return &ProcessManyBuilder{
+		ctx:       ctx,
+		orders:    o,
+		batchSize: DefaultProcessBatchSize,
+		err:       make([]error, 0),
+	}
+}
+
+// OrderIds appends orders to process. Repeated ids are processed once and reported as skipped.
+func (b *ProcessManyBuilder) OrderIds(ids []strfmt.UUID) *ProcessManyBuilder {
+	if b == nil {
+		return nil
+	}
+	for _, id := range ids {
+		if !strfmt.IsUUID(id.String()) {
+			b.err = append(b.err, fmt.Errorf("orderId %q is not a valid uuid", id))
+			return b
+		}
+	}
+	b.ids = append(b.ids, ids...)
+	return b
+}
+
+func (b *ProcessManyBuilder) LocationID(location strfmt.UUID) *ProcessManyBuilder {
+	if b == nil {
+		return nil
+	}
+	if !strfmt.IsUUID(location.String()) {
+		b.err = append(b.err, fmt.Errorf("locationId %q is not a valid uuid", location))
+		return b
+	}
+	b.location = location
+	return b
+}
+
+// ClientContext is sent with every batch.
+func (b *ProcessManyBuilder) ClientContext(value *models.ClientContext) *ProcessManyBuilder {
+	if b == nil {
+		return nil
+	}
+	if value == nil {
+		b.err = append(b.err, errors.New("clientContext cannot be nil"))
+		return b
+	}
+	b.clientContext = value
+	return b
+}
+
+func (b *ProcessManyBuilder) BatchSize(size int) *ProcessManyBuilder {
+	if b == nil {
+		return nil
+	}
+	if size <= 0 {
+		b.err = append(b.err, errors.New("batchSize must be greater than 0"))
+		return b
+	}
+	b.batchSize = size
+	return b
+}
+
+// StopOnError skips the remaining batches once a batch call fails or ends with an unknown outcome. By default the next batches are still sent.
+func (b *ProcessManyBuilder) StopOnError(value bool) *ProcessManyBuilder {
+	if b == nil {
+		return nil
+	}
+	b.stopOnError = value
+	return b
+}
+
+// RetryPolicy overrides the client retry policy for every batch.
+func (b *ProcessManyBuilder) RetryPolicy(policy lw_api.RetryPolicy) *ProcessManyBuilder {
+	return b.WithOptions(lw_api.CallRetryPolicy(policy))
+}
+
+// WithOptions applies per-call options to every batch.
+func (b *ProcessManyBuilder) WithOptions(opts ...lw_api.CallOption) *ProcessManyBuilder {
+	if b == nil {
+		return nil
+	}
+	b.opts = append(b.opts, opts...)
+	return b
+}
+
+func (b *ProcessManyBuilder) build() error {
+	if b == nil {
+		return errors.New("builder is nil")
+	}
+	errs := make([]error, len(b.err))
+	copy(errs, b.err)
+	if len(b.ids) == 0 {
+		errs = append(errs, errors.New("orderIds must contain at least one value"))
+	}
+	return errors.Join(errs...)
+}
+
+// Do sends the batches one after another. The error is only about the builder itself: failed
+// orders and batches end up in the report.
+func (b *ProcessManyBuilder) Do() (*ProcessReport, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	if err := b.build(); err != nil {
+		return nil, err
+	}
+	report := &ProcessReport{}
+	seen := make(map[strfmt.UUID]bool, len(b.ids))
+	ids := make([]strfmt.UUID, 0, len(b.ids))
+	for _, id := range b.ids {
+		if seen[id] {
+			report.Skipped = append(report.Skipped, ProcessOutcome{OrderID: id, Error: "duplicate order id"})
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	// a payload Linnworks would reject is the same for every batch: report it as a builder error
+	if _, err := b.batch(ids[:min(b.batchSize, len(ids))]).build(); err != nil {
+		return nil, err
+	}
+
+	var stopped string
+	for start := 0; start < len(ids); start += b.batchSize {
+		batch := ids[start:min(start+b.batchSize, len(ids))]
+		if stopped == "" {
+			if err := b.ctx.Err(); err != nil {
+				stopped = err.Error()
+			}
+		}
+		if stopped != "" {
+			for _, id := range batch {
+				report.Skipped = append(report.Skipped, ProcessOutcome{OrderID: id, Error: stopped})
+			}
+			continue
+		}
+		results, err := b.batch(batch).Do()
+		if err != nil {
+			report.fail(batch, err)
+			if b.stopOnError {
+				stopped = "not sent: previous batch failed"
+			}
+			continue
+		}
+		report.add(batch, results)
+	}
+	return report, nil
+}
+
+func (b *ProcessManyBuilder) batch(ids []strfmt.UUID) *ProcessOrdersInBatchRequestBuilder {
+	req := b.orders.ProcessOrdersInBatch(b.ctx).OrdersIds(ids).WithOptions(b.opts...)
+	if b.location != "" {
+		req.LocationID(b.location)
+	}
+	if b.clientContext != nil {
+		req.Context(b.clientContext)
+	}
+	return req
+}
+
+// fail files the orders of a failed batch call by what Linnworks may have done with them.
+func (r *ProcessReport) fail(batch []strfmt.UUID, err error) {
+	bucket := &r.Unknown
+	if lw_api.IsNotSent(err) || lw_api.IsRateLimited(err) {
+		// a 429 is answered before the batch is processed
+		bucket = &r.Skipped
+	} else if apiErr, ok := lw_api.AsAPIError(err); ok && apiErr.Status < http.StatusInternalServerError {
+		bucket = &r.Failed
+	}
+	for _, id := range batch {
+		*bucket = append(*bucket, ProcessOutcome{OrderID: id, Error: err.Error()})
+	}
+}
+
+func (r *ProcessReport) add(batch []strfmt.UUID, results []models.ProcessOrderResult) {
+	byID := make(map[strfmt.UUID]models.ProcessOrderResult, len(results))
+	for _, res := range results {
+		byID[res.OrderID] = res
+	}
+	for _, id := range batch {
+		res, ok := byID[id]
+		switch {
+		case !ok:
+			r.Unknown = append(r.Unknown, ProcessOutcome{OrderID: id, Error: "no result returned"})
+		case res.Processed:
+			r.Succeeded = append(r.Succeeded, id)
+		case res.Error != "":
+			r.Failed = append(r.Failed, ProcessOutcome{OrderID: id, Error: res.Error})
+		default:
+			r.Failed = append(r.Failed, ProcessOutcome{OrderID: id, Error: "not processed"})
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package orders_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/lwmock"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+func orderIDs(n int) []strfmt.UUID {
+	out := make([]strfmt.UUID, n)
+	for i := range out {
+		out[i] = strfmt.UUID(fmt.Sprintf("00000000-0000-4000-8000-%012d", i+1))
+	}
+	return out
+}
+
+func TestProcessMany(t *testing.T) {
+	ctx := context.Background()
+	location := strfmt.UUID("00000000-0000-0000-0000-000000000000")
+
+	t.Run("should split ids into batches and sort results", func(t *testing.T) {
+		m := lwmock.New()
+		ids := orderIDs(5)
+		m.OnProcessOrdersInBatch(func(req *models.OrdersProcessOrdersInBatchRequest) ([]models.ProcessOrderResult, error) {
+			if req.Context == nil || req.Context.Module != "dispatch" {
+				t.Errorf("Expected client context to be passed, got %+v", req.Context)
+			}
+			var out []models.ProcessOrderResult
+			for _, id := range req.OrdersIds {
+				switch id {
+				case ids[1]:
+					out = append(out, models.ProcessOrderResult{OrderID: id, Error: "order is parked"})
+				case ids[4]:
+				default:
+					out = append(out, models.ProcessOrderResult{OrderID: id, Processed: true})
+				}
+			}
+			return out, nil
+		})
+		report, err := m.ProcessMany(ctx).
+			OrderIds(ids).
+			OrderIds(ids[:1]).
+			LocationID(location).
+			ClientContext(&models.ClientContext{Module: "dispatch"}).
+			BatchSize(2).
+			Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if calls := m.Calls("/api/Orders/ProcessOrdersInBatch"); len(calls) != 3 {
+			t.Errorf("Expected 3 batches, got %d", len(calls))
+		}
+		if !slices.Equal(report.Succeeded, []strfmt.UUID{ids[0], ids[2], ids[3]}) {
+			t.Errorf("unexpected succeeded %v", report.Succeeded)
+		}
+		if len(report.Failed) != 1 || report.Failed[0].OrderID != ids[1] || report.Failed[0].Error != "order is parked" {
+			t.Errorf("unexpected failed %+v", report.Failed)
+		}
+		if len(report.Skipped) != 1 || report.Skipped[0].Error != "duplicate order id" {
+			t.Errorf("unexpected skipped %+v", report.Skipped)
+		}
+		if len(report.Unknown) != 1 || report.Unknown[0].OrderID != ids[4] {
+			t.Errorf("unexpected unknown %+v", report.Unknown)
+		}
+		if report.Err() == nil {
+			t.Error("Expected report error")
+		}
+	})
+
+	t.Run("should fail the batch and skip the rest when asked to stop", func(t *testing.T) {
+		m := lwmock.New()
+		m.OnProcessOrdersInBatch(func(req *models.OrdersProcessOrdersInBatchRequest) ([]models.ProcessOrderResult, error) {
+			return nil, &client.APIError{Status: 400, Message: "bad request"}
+		})
+		report, err := m.ProcessMany(ctx).OrderIds(orderIDs(3)).BatchSize(2).StopOnError(true).Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if len(report.Succeeded) != 0 || len(report.Failed) != 2 || len(report.Skipped) != 1 {
+			t.Errorf("unexpected report %+v", report)
+		}
+		if calls := m.Calls("/api/Orders/ProcessOrdersInBatch"); len(calls) != 1 {
+			t.Errorf("Expected 1 batch, got %d", len(calls))
+		}
+	})
+
+	t.Run("should tell unsent batches from batches with an unknown outcome", func(t *testing.T) {
+		m := lwmock.New()
+		ids := orderIDs(5)
+		m.OnProcessOrdersInBatch(func(req *models.OrdersProcessOrdersInBatchRequest) ([]models.ProcessOrderResult, error) {
+			switch req.OrdersIds[0] {
+			case ids[0]:
+				return nil, &client.APIError{Status: 400, Message: "bad request"}
+			case ids[1]:
+				return nil, &client.NotSentError{Err: errors.New("connection refused")}
+			case ids[2]:
+				return nil, &client.APIError{Status: 502, Message: "bad gateway"}
+			case ids[4]:
+				return nil, &client.APIError{Status: 429, Message: "too many requests"}
+			}
+			return nil, errors.New("connection reset by peer")
+		})
+		report, err := m.ProcessMany(ctx).OrderIds(ids).BatchSize(1).Do()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		if len(report.Failed) != 1 || report.Failed[0].OrderID != ids[0] {
+			t.Errorf("unexpected failed %+v", report.Failed)
+		}
+		if len(report.Skipped) != 2 || report.Skipped[0].OrderID != ids[1] || report.Skipped[1].OrderID != ids[4] {
+			t.Errorf("unexpected skipped %+v", report.Skipped)
+		}
+		if len(report.Unknown) != 2 || report.Unknown[0].OrderID != ids[2] || report.Unknown[1].OrderID != ids[3] {
+			t.Errorf("unexpected unknown %+v", report.Unknown)
+		}
+	})
+
+	t.Run("should reject invalid input before sending", func(t *testing.T) {
+		m := lwmock.New()
+		_, err := m.ProcessMany(ctx).OrderIds([]strfmt.UUID{"nope"}).BatchSize(0).Do()
+		if err == nil {
+			t.Fatal("Expected error")
+		}
+		if len(m.Calls("")) != 0 {
+			t.Error("Expected no calls")
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package orders
+
+import (
+	"errors"
+
+	"github.com/go-openapi/strfmt"
+
+	lw_api "github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+// OrderOrReferenceID accepts the order number or the channel reference.
+func (b *ProcessOrderByOrderOrReferenceIdRequestBuilder) OrderOrReferenceID(value string) *ProcessOrderByOrderOrReferenceIdRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	if value == "" {
+		b.err = append(b.err, errors.New("orderOrReferenceId cannot be empty"))
+		return b
+	}
+	b.request().OrderOrReferenceID = value
+	return b
+}
+
+func (b *ProcessOrderByOrderOrReferenceIdRequestBuilder) LocationID(location strfmt.UUID) *ProcessOrderByOrderOrReferenceIdRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	b.request().LocationID = location
+	return b
+}
+
+func (b *ProcessOrderByOrderOrReferenceIdRequestBuilder) ScansPerformed(value bool) *ProcessOrderByOrderOrReferenceIdRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	b.request().ScansPerformed = value
+	return b
+}
+
+// OrderProcessingNotesAcknowledged answers the NOTE_ACKNOWLEDGEMENT_REQUIRED state of a previous call.
+func (b *ProcessOrderByOrderOrReferenceIdRequestBuilder) OrderProcessingNotesAcknowledged(value bool) *ProcessOrderByOrderOrReferenceIdRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	b.request().OrderProcessingNotesAcknowledged = value
+	return b
+}
+
+func (b *ProcessOrderByOrderOrReferenceIdRequestBuilder) WorkflowJobID(id int32) *ProcessOrderByOrderOrReferenceIdRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	if id <= 0 {
+		b.err = append(b.err, errors.New("workflowJobId must be greater than 0"))
+		return b
+	}
+	b.request().WorkflowJobID = id
+	return b
+}
+
+// request returns the wrapped request the setters above fill in.
+func (b *ProcessOrderByOrderOrReferenceIdRequestBuilder) request() *models.ProcessOrderByOrderIDOrReferenceRequest {
+	if b.data.Request == nil {
+		b.data.Request = &models.ProcessOrderByOrderIDOrReferenceRequest{}
+	}
+	return b.data.Request
+}
+
+func (b *ProcessOrderByOrderOrReferenceIdRequestBuilder) build() (*models.OrdersProcessOrderByOrderOrReferenceIDRequest, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	errs := make([]error, len(b.err))
+	copy(errs, b.err)
+	if b.data.Request == nil || b.data.Request.OrderOrReferenceID == "" {
+		errs = append(errs, errors.New("orderOrReferenceId is required"))
+	}
+	errs = append(errs, lw_api.ValidatePayload(b.ctx, b.data)...)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return b.data, nil
+}
@@ -0,0 +1,79 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-openapi/strfmt"
+
+	lw_api "github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+func (b *ProcessOrderRequiredBatchScansRequestBuilder) OrderID(id strfmt.UUID) *ProcessOrderRequiredBatchScansRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	b.assignment().OrderID = id
+	return b
+}
+
+// Batch assigns quantity units of the order item row to a batch; call it once per row and batch.
+func (b *ProcessOrderRequiredBatchScansRequestBuilder) Batch(orderItemRowID strfmt.UUID, batchInventoryID, quantity int32) *ProcessOrderRequiredBatchScansRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	if !strfmt.IsUUID(orderItemRowID.String()) {
+		b.err = append(b.err, fmt.Errorf("orderItemRowId %q is not a valid uuid", orderItemRowID))
+		return b
+	}
+	if batchInventoryID <= 0 {
+		b.err = append(b.err, errors.New("batchInventoryId must be greater than 0"))
+		return b
+	}
+	if quantity <= 0 {
+		b.err = append(b.err, errors.New("quantity must be greater than 0"))
+		return b
+	}
+	a := b.assignment()
+	for _, m := range a.BatchToItemMapping {
+		if m.OrderItemRowID == orderItemRowID && m.BatchInventoryID == batchInventoryID {
+			b.err = append(b.err, fmt.Errorf("batch %d is already assigned to row %s", batchInventoryID, orderItemRowID))
+			return b
+		}
+	}
+	a.BatchToItemMapping = append(a.BatchToItemMapping, &models.BatchAssignment{
+		OrderItemRowID:   orderItemRowID,
+		BatchInventoryID: batchInventoryID,
+		Quantity:         quantity,
+	})
+	return b
+}
+
+// assignment returns the wrapped batch assignment the setters above fill in.
+func (b *ProcessOrderRequiredBatchScansRequestBuilder) assignment() *models.BatchAssignmentForOrderItems {
+	if b.data.BatchAssignment == nil {
+		b.data.BatchAssignment = &models.BatchAssignmentForOrderItems{}
+	}
+	return b.data.BatchAssignment
+}
+
+func (b *ProcessOrderRequiredBatchScansRequestBuilder) build() (*models.OrdersProcessOrderRequiredBatchScansRequest, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	errs := make([]error, len(b.err))
+	copy(errs, b.err)
+	a := b.assignment()
+	if a.OrderID == "" {
+		errs = append(errs, errors.New("orderId is required"))
+	}
+	if len(a.BatchToItemMapping) == 0 {
+		errs = append(errs, errors.New("batchToItemMapping must contain at least one value"))
+	}
+	errs = append(errs, lw_api.ValidatePayload(b.ctx, b.data)...)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return b.data, nil
+}
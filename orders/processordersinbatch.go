@@ -0,0 +1,36 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-openapi/strfmt"
+
+	lw_api "github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+// build also rejects repeated order ids: Linnworks returns a single result for them.
+func (b *ProcessOrdersInBatchRequestBuilder) build() (*models.OrdersProcessOrdersInBatchRequest, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	errs := make([]error, len(b.err))
+	copy(errs, b.err)
+	if len(b.data.OrdersIds) == 0 {
+		errs = append(errs, errors.New("ordersIds must contain at least one value"))
+	}
+	seen := make(map[strfmt.UUID]bool, len(b.data.OrdersIds))
+	for _, id := range b.data.OrdersIds {
+		if seen[id] {
+			errs = append(errs, fmt.Errorf("ordersIds contains %s more than once", id))
+			break
+		}
+		seen[id] = true
+	}
+	errs = append(errs, lw_api.ValidatePayload(b.ctx, b.data)...)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return b.data, nil
+}
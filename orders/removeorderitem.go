@@ -0,0 +1,17 @@
+package orders
+
+import "errors"
+
+func (b *RemoveOrderItemRequestBuilder) requiredField() []error {
+	var errs []error
+	if b.data.FulfilmentCenter == "" {
+		errs = append(errs, errors.New("fulfilmentCenter is required"))
+	}
+	if b.data.OrderID == "" {
+		errs = append(errs, errors.New("orderId is required"))
+	}
+	if b.data.Rowid == "" {
+		errs = append(errs, errors.New("rowid is required"))
+	}
+	return errs
+}
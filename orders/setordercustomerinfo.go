@@ -0,0 +1,14 @@
+package orders
+
+import "errors"
+
+func (b *SetOrderCustomerInfoRequestBuilder) requiredField() []error {
+	var errs []error
+	if b.data.Info == nil {
+		errs = append(errs, errors.New("info is required"))
+	}
+	if b.data.OrderID == "" {
+		errs = append(errs, errors.New("orderId is required"))
+	}
+	return errs
+}
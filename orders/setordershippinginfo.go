@@ -0,0 +1,34 @@
+package orders
+
+import (
+	"errors"
+
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+func (b *SetOrderShippingInfoRequestBuilder) Info(info *models.UpdateOrderShippingInfoRequest) *SetOrderShippingInfoRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	if info == nil {
+		b.err = append(b.err, errors.New("info cannot be nil"))
+		return b
+	}
+	if info.PostageCost < 0 {
+		b.err = append(b.err, fieldError("$.info.PostageCost", "min", info.PostageCost, "must not be negative"))
+		return b
+	}
+	b.data.Info = info
+	return b
+}
+
+func (b *SetOrderShippingInfoRequestBuilder) requiredField() []error {
+	var errs []error
+	if b.data.Info == nil {
+		errs = append(errs, errors.New("info is required"))
+	}
+	if b.data.OrderID == "" {
+		errs = append(errs, errors.New("orderId is required"))
+	}
+	return errs
+}
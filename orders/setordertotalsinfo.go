@@ -0,0 +1,34 @@
+package orders
+
+import (
+	"errors"
+
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+func (b *SetOrderTotalsInfoRequestBuilder) Info(info *models.OrderTotalsInfo) *SetOrderTotalsInfoRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	if info == nil {
+		b.err = append(b.err, errors.New("info cannot be nil"))
+		return b
+	}
+	if info.Currency != "" && !isUpperAlpha(info.Currency, 3) {
+		b.err = append(b.err, fieldError("$.info.Currency", "pattern", info.Currency, "must be a 3 letter ISO 4217 code"))
+		return b
+	}
+	b.data.Info = info
+	return b
+}
+
+func (b *SetOrderTotalsInfoRequestBuilder) requiredField() []error {
+	var errs []error
+	if b.data.Info == nil {
+		errs = append(errs, errors.New("info is required"))
+	}
+	if b.data.OrderID == "" {
+		errs = append(errs, errors.New("orderId is required"))
+	}
+	return errs
+}
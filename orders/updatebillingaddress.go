@@ -0,0 +1,14 @@
+package orders
+
+import "errors"
+
+func (b *UpdateBillingAddressRequestBuilder) requiredField() []error {
+	var errs []error
+	if b.data.BillingAddress == nil {
+		errs = append(errs, errors.New("billingAddress is required"))
+	}
+	if b.data.OrderID == "" {
+		errs = append(errs, errors.New("orderId is required"))
+	}
+	return errs
+}
@@ -0,0 +1,30 @@
+package orders
+
+import "errors"
+
+func (b *UpdateLinkItemRequestBuilder) Source(source, subSource string) *UpdateLinkItemRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	if source == "" || subSource == "" {
+		b.err = append(b.err, errors.New("source and subSource cannot be empty"))
+		return b
+	}
+	b.data.Source = source
+	b.data.SubSource = subSource
+	return b
+}
+
+func (b *UpdateLinkItemRequestBuilder) requiredField() []error {
+	var errs []error
+	if b.data.ChannelSKU == "" {
+		errs = append(errs, errors.New("channelSKU is required"))
+	}
+	if b.data.PkStockItemID == "" {
+		errs = append(errs, errors.New("pkStockItemId is required"))
+	}
+	if b.data.Source == "" {
+		errs = append(errs, errors.New("source is required"))
+	}
+	return errs
+}
@@ -0,0 +1,48 @@
+package orders
+
+import (
+	"errors"
+
+	"github.com/MMC-BK/lw-api/orders/models"
+)
+
+// OrderItem is the full new state of the line; fields left empty are cleared.
+func (b *UpdateOrderItemRequestBuilder) OrderItem(item *models.OrderItem) *UpdateOrderItemRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	if item == nil {
+		b.err = append(b.err, errors.New("orderItem cannot be nil"))
+		return b
+	}
+	if item.RowID == "" {
+		b.err = append(b.err, errors.New("orderItem.RowId is required"))
+		return b
+	}
+	b.data.OrderItem = item
+	return b
+}
+
+// Source and SubSource of the order let Linnworks keep the channel link of the line.
+func (b *UpdateOrderItemRequestBuilder) Source(source, subSource string) *UpdateOrderItemRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	b.data.Source = source
+	b.data.SubSource = subSource
+	return b
+}
+
+func (b *UpdateOrderItemRequestBuilder) requiredField() []error {
+	var errs []error
+	if b.data.FulfilmentCenter == "" {
+		errs = append(errs, errors.New("fulfilmentCenter is required"))
+	}
+	if b.data.OrderID == "" {
+		errs = append(errs, errors.New("orderId is required"))
+	}
+	if b.data.OrderItem == nil {
+		errs = append(errs, errors.New("orderItem is required"))
+	}
+	return errs
+}
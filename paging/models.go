@@ -0,0 +1,40 @@
+package paging
+
+import (
+	inventorymodels "github.com/MMC-BK/lw-api/inventory/models"
+	ordermodels "github.com/MMC-BK/lw-api/orders/models"
+	processedmodels "github.com/MMC-BK/lw-api/processedorders/models"
+)
+
+func FromProcessedOrderWeb(r *processedmodels.GenericPagedResultProcessedOrderWeb) Page[*processedmodels.ProcessedOrderWeb] {
+	if r == nil {
+		return Page[*processedmodels.ProcessedOrderWeb]{}
+	}
+	return Page[*processedmodels.ProcessedOrderWeb]{Items: r.Data, PageNumber: r.PageNumber, TotalPages: r.TotalPages}
+}
+
+func FromOpenOrder(r *ordermodels.GenericPagedResultOpenOrder) Page[*ordermodels.OpenOrder] {
+	if r == nil {
+		return Page[*ordermodels.OpenOrder]{}
+	}
+	return Page[*ordermodels.OpenOrder]{Items: r.Data, PageNumber: r.PageNumber, TotalPages: r.TotalPages}
+}
+
+func FromScrapItem(r *inventorymodels.GenericPagedResultScrapItem) Page[*inventorymodels.ScrapItem] {
+	if r == nil {
+		return Page[*inventorymodels.ScrapItem]{}
+	}
+	return Page[*inventorymodels.ScrapItem]{Items: r.Data, PageNumber: r.PageNumber, TotalPages: r.TotalPages}
+}
+
+// FromStockItemAuditTrail computes TotalPages from TotalItems since the model does not carry it.
+func FromStockItemAuditTrail(r *inventorymodels.PagedResultStockItemAuditTrail) Page[*inventorymodels.StockItemAuditTrail] {
+	if r == nil {
+		return Page[*inventorymodels.StockItemAuditTrail]{}
+	}
+	return Page[*inventorymodels.StockItemAuditTrail]{
+		Items:      r.Items,
+		PageNumber: r.CurrentPage,
+		TotalPages: TotalPages(int64(r.TotalItems), int64(r.EntriesPerPage)),
+	}
+}
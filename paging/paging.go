@@ -0,0 +1,146 @@
+package paging
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// Page is a single page of results normalised from Linnworks' paged response models.
+type Page[T any] struct {
+	Items      []T
+	PageNumber int32
+	TotalPages int32
+}
+
+// FetchFunc loads the page with the given 1-based number.
+type FetchFunc[T any] func(ctx context.Context, page int32) (Page[T], error)
+
+// ErrStop may be returned from a ForEach callback to stop iteration without an error.
+var ErrStop = errors.New("paging: stop")
+
+type config struct {
+	startPage int32
+	maxPages  int
+	maxItems  int
+}
+
+type Option func(*config)
+
+// StartPage sets the first page to fetch, 1 by default.
+func StartPage(page int32) Option {
+	return func(c *config) {
+		if page > 0 {
+			c.startPage = page
+		}
+	}
+}
+
+// MaxPages caps the number of pages fetched; 0 means no limit.
+func MaxPages(n int) Option {
+	return func(c *config) { c.maxPages = n }
+}
+
+// MaxItems caps the number of items yielded; 0 means no limit.
+func MaxItems(n int) Option {
+	return func(c *config) { c.maxItems = n }
+}
+
+type Pager[T any] struct {
+	fetch FetchFunc[T]
+	cfg   config
+}
+
+func New[T any](fetch FetchFunc[T], opts ...Option) *Pager[T] {
+	cfg := config{startPage: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Pager[T]{fetch: fetch, cfg: cfg}
+}
+
+// Pages yields every page until TotalPages is reached, an empty page is returned,
+// a cap is hit or ctx is done. Iteration stops after the first error.
+func (p *Pager[T]) Pages(ctx context.Context) iter.Seq2[Page[T], error] {
+	return func(yield func(Page[T], error) bool) {
+		if p == nil || p.fetch == nil {
+			yield(Page[T]{}, errors.New("pager is nil"))
+			return
+		}
+		fetched := 0
+		for number := p.cfg.startPage; ; number++ {
+			if p.cfg.maxPages > 0 && fetched >= p.cfg.maxPages {
+				return
+			}
+			if err := ctx.Err(); err != nil {
+				yield(Page[T]{}, err)
+				return
+			}
+			page, err := p.fetch(ctx, number)
+			if err != nil {
+				yield(Page[T]{}, err)
+				return
+			}
+			fetched++
+			if page.PageNumber == 0 {
+				page.PageNumber = number
+			}
+			if !yield(page, nil) {
+				return
+			}
+			if len(page.Items) == 0 || page.PageNumber >= page.TotalPages {
+				return
+			}
+		}
+	}
+}
+
+// All yields items across pages, honouring MaxItems.
+func (p *Pager[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yielded := 0
+		for page, err := range p.Pages(ctx) {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Items {
+				if p.cfg.maxItems > 0 && yielded >= p.cfg.maxItems {
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+				yielded++
+			}
+			if p.cfg.maxItems > 0 && yielded >= p.cfg.maxItems {
+				return
+			}
+		}
+	}
+}
+
+// ForEach calls fn for every item. Returning ErrStop from fn ends iteration with a nil error.
+func (p *Pager[T]) ForEach(ctx context.Context, fn func(T) error) error {
+	for item, err := range p.All(ctx) {
+		if err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			if errors.Is(err, ErrStop) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// TotalPages derives the page count for responses that only report item totals.
+func TotalPages(totalItems, perPage int64) int32 {
+	if perPage <= 0 || totalItems <= 0 {
+		return 0
+	}
+	return int32((totalItems + perPage - 1) / perPage)
+}
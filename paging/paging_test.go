@@ -0,0 +1,81 @@
+package paging
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func pagesOf(total int32, perPage int) FetchFunc[int] {
+	return func(ctx context.Context, page int32) (Page[int], error) {
+		items := make([]int, 0, perPage)
+		for i := 0; i < perPage; i++ {
+			items = append(items, int(page-1)*perPage+i)
+		}
+		return Page[int]{Items: items, PageNumber: page, TotalPages: total}, nil
+	}
+}
+
+func TestPager_All(t *testing.T) {
+	t.Run("should walk every page exactly once", func(t *testing.T) {
+		var got []int
+		for item, err := range New(pagesOf(3, 2)).All(context.Background()) {
+			if err != nil {
+				t.Fatalf("Expected nil error, got %v", err)
+			}
+			got = append(got, item)
+		}
+		if len(got) != 6 || got[0] != 0 || got[5] != 5 {
+			t.Errorf("Expected items 0..5, got %v", got)
+		}
+	})
+
+	t.Run("should respect MaxPages and MaxItems", func(t *testing.T) {
+		count := 0
+		for range New(pagesOf(10, 2), MaxPages(2)).All(context.Background()) {
+			count++
+		}
+		if count != 4 {
+			t.Errorf("Expected 4 items, got %d", count)
+		}
+		count = 0
+		for range New(pagesOf(10, 2), MaxItems(3)).All(context.Background()) {
+			count++
+		}
+		if count != 3 {
+			t.Errorf("Expected 3 items, got %d", count)
+		}
+	})
+
+	t.Run("should stop on cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := New(pagesOf(3, 2)).ForEach(ctx, func(int) error { return nil })
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("ForEach should treat ErrStop as success", func(t *testing.T) {
+		seen := 0
+		err := New(pagesOf(3, 2)).ForEach(context.Background(), func(int) error {
+			seen++
+			if seen == 3 {
+				return ErrStop
+			}
+			return nil
+		})
+		if err != nil || seen != 3 {
+			t.Errorf("Expected nil error after 3 items, got %v after %d", err, seen)
+		}
+	})
+}
+
+func TestTotalPages(t *testing.T) {
+	if got := TotalPages(201, 100); got != 3 {
+		t.Errorf("Expected 3, got %d", got)
+	}
+	if got := TotalPages(0, 100); got != 0 {
+		t.Errorf("Expected 0, got %d", got)
+	}
+}
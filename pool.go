@@ -0,0 +1,375 @@
+package lw_api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	client2 "github.com/MMC-BK/lw-api/client"
+)
+
+// ClientPool hands out one *LinnworksAPI per Linnworks installation token.
+// All clients share one http.Transport; each keeps its own token source and rate limiter.
+type ClientPool struct {
+	appID       string
+	appSecret   string
+	configure   func(token string, b *LinnworksAPIBuilder)
+	maxSize     int
+	idleTimeout time.Duration
+	transport   *http.Transport
+
+	mu      sync.Mutex
+	tenants map[string]*tenant
+	closed  bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+type tenant struct {
+	api      *LinnworksAPI
+	id       string
+	created  time.Time
+	lastUsed atomic.Int64
+	requests atomic.Int64
+	errors   atomic.Int64
+	latency  atomic.Int64
+}
+
+// TenantMetrics is a snapshot of per-tenant counters. TenantID is a fingerprint
+// of the installation token so metrics can be exported without leaking it.
+type TenantMetrics struct {
+	TenantID        string
+	Requests        int64
+	Errors          int64
+	TotalLatency    time.Duration
+	Refreshes       int64
+	ResignedRetries int64
+	Created         time.Time
+	LastUsed        time.Time
+}
+
+type ClientPoolBuilder struct {
+	appID       string
+	appSecret   string
+	configure   func(token string, b *LinnworksAPIBuilder)
+	maxSize     int
+	idleTimeout time.Duration
+	transport   *http.Transport
+	err         []error
+}
+
+func NewClientPoolBuilder() *ClientPoolBuilder {
+	return &ClientPoolBuilder{err: make([]error, 0)}
+}
+
+func (b *ClientPoolBuilder) AppID(appID string) *ClientPoolBuilder {
+	if b == nil {
+		return nil
+	}
+	if appID == "" {
+		b.err = append(b.err, errors.New("applicationID is required"))
+		return b
+	}
+	b.appID = appID
+	return b
+}
+
+func (b *ClientPoolBuilder) AppSecret(appSecret string) *ClientPoolBuilder {
+	if b == nil {
+		return nil
+	}
+	if appSecret == "" {
+		b.err = append(b.err, errors.New("applicationSecret is required"))
+		return b
+	}
+	b.appSecret = appSecret
+	return b
+}
+
+// Configure is called for every new tenant client, e.g. to set BaseURL or a per-tenant TokenStore.
+// AppID, AppSecret, Token and Transport are set by the pool.
+func (b *ClientPoolBuilder) Configure(fn func(token string, b *LinnworksAPIBuilder)) *ClientPoolBuilder {
+	if b == nil {
+		return nil
+	}
+	b.configure = fn
+	return b
+}
+
+// MaxSize caps the number of cached clients; the least recently used one is evicted. 0 means no limit.
+func (b *ClientPoolBuilder) MaxSize(n int) *ClientPoolBuilder {
+	if b == nil {
+		return nil
+	}
+	if n < 0 {
+		b.err = append(b.err, errors.New("maxSize must not be negative"))
+		return b
+	}
+	b.maxSize = n
+	return b
+}
+
+// IdleTimeout evicts clients that were not used for d. 0 disables idle eviction.
+func (b *ClientPoolBuilder) IdleTimeout(d time.Duration) *ClientPoolBuilder {
+	if b == nil {
+		return nil
+	}
+	if d < 0 {
+		b.err = append(b.err, errors.New("idleTimeout must not be negative"))
+		return b
+	}
+	b.idleTimeout = d
+	return b
+}
+
+// Transport replaces the shared transport, a clone of http.DefaultTransport by default.
+func (b *ClientPoolBuilder) Transport(t *http.Transport) *ClientPoolBuilder {
+	if b == nil {
+		return nil
+	}
+	if t == nil {
+		b.err = append(b.err, errors.New("transport cannot be nil"))
+		return b
+	}
+	b.transport = t
+	return b
+}
+
+func (b *ClientPoolBuilder) Build() (*ClientPool, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	errs := make([]error, len(b.err))
+	copy(errs, b.err)
+	if b.appID == "" {
+		errs = append(errs, errors.New("applicationID is required"))
+	}
+	if b.appSecret == "" {
+		errs = append(errs, errors.New("applicationSecret is required"))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	transport := b.transport
+	if transport == nil {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConnsPerHost = 32
+	}
+	p := &ClientPool{
+		appID:       b.appID,
+		appSecret:   b.appSecret,
+		configure:   b.configure,
+		maxSize:     b.maxSize,
+		idleTimeout: b.idleTimeout,
+		transport:   transport,
+		tenants:     make(map[string]*tenant),
+	}
+	if p.idleTimeout > 0 {
+		p.stop = make(chan struct{})
+		p.done = make(chan struct{})
+		go p.janitor()
+	}
+	return p, nil
+}
+
+// Get returns the client for an installation token, building it on first use.
+func (p *ClientPool) Get(token string) (*LinnworksAPI, error) {
+	if token == "" {
+		return nil, errors.New("token is required")
+	}
+	api, evicted, err := p.get(token)
+	if evicted != nil {
+		evicted.api.Close()
+	}
+	return api, err
+}
+
+// get looks up or builds the client under the lock and hands back the tenant it evicted,
+// so that the caller closes it without holding p.mu.
+func (p *ClientPool) get(token string) (*LinnworksAPI, *tenant, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil, nil, errors.New("client pool is closed")
+	}
+	if t, ok := p.tenants[token]; ok {
+		t.lastUsed.Store(time.Now().UnixNano())
+		return t.api, nil, nil
+	}
+
+	t := &tenant{id: TenantID(token), created: time.Now()}
+	t.lastUsed.Store(t.created.UnixNano())
+	builder := NewLinnworksAPIBuilder()
+	if p.configure != nil {
+		p.configure(token, builder)
+	}
+	api, err := builder.
+		AppID(p.appID).
+		AppSecret(p.appSecret).
+		Token(token).
+		Transport(p.transport).
+		Middleware(t.metricsMiddleware()).
+		Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	t.api = api
+
+	var evicted *tenant
+	if p.maxSize > 0 && len(p.tenants) >= p.maxSize {
+		evicted = p.evictLRULocked()
+	}
+	p.tenants[token] = t
+	return api, evicted, nil
+}
+
+// Remove drops and closes the client for token, if cached.
+func (p *ClientPool) Remove(token string) {
+	p.mu.Lock()
+	t := p.removeLocked(token)
+	p.mu.Unlock()
+	if t != nil {
+		t.api.Close()
+	}
+}
+
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.tenants)
+}
+
+// Metrics returns a snapshot for every cached tenant.
+func (p *ClientPool) Metrics() []TenantMetrics {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]TenantMetrics, 0, len(p.tenants))
+	for _, t := range p.tenants {
+		out = append(out, t.metrics())
+	}
+	return out
+}
+
+// Close stops idle eviction and closes every cached client.
+func (p *ClientPool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	var removed []*tenant
+	for token := range p.tenants {
+		removed = append(removed, p.removeLocked(token))
+	}
+	p.mu.Unlock()
+	var errs []error
+	for _, t := range removed {
+		errs = append(errs, t.api.Close())
+	}
+	if p.stop != nil {
+		close(p.stop)
+		<-p.done
+	}
+	p.transport.CloseIdleConnections()
+	return errors.Join(errs...)
+}
+
+// EvictIdle removes clients idle for longer than the idle timeout and reports how many were removed.
+func (p *ClientPool) EvictIdle() int {
+	if p.idleTimeout <= 0 {
+		return 0
+	}
+	cutoff := time.Now().Add(-p.idleTimeout).UnixNano()
+	p.mu.Lock()
+	var removed []*tenant
+	for token, t := range p.tenants {
+		if t.lastUsed.Load() < cutoff {
+			removed = append(removed, p.removeLocked(token))
+		}
+	}
+	p.mu.Unlock()
+	for _, t := range removed {
+		t.api.Close()
+	}
+	return len(removed)
+}
+
+func (p *ClientPool) janitor() {
+	defer close(p.done)
+	ticker := time.NewTicker(p.idleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.EvictIdle()
+		}
+	}
+}
+
+func (p *ClientPool) evictLRULocked() *tenant {
+	var oldest string
+	var oldestUsed int64
+	for token, t := range p.tenants {
+		if used := t.lastUsed.Load(); oldest == "" || used < oldestUsed {
+			oldest, oldestUsed = token, used
+		}
+	}
+	return p.removeLocked(oldest)
+}
+
+// removeLocked drops the tenant from the map; closing it is left to the caller,
+// after p.mu is released, since Close waits for the token refresh loop to stop.
+func (p *ClientPool) removeLocked(token string) *tenant {
+	t, ok := p.tenants[token]
+	if !ok {
+		return nil
+	}
+	delete(p.tenants, token)
+	return t
+}
+
+func (t *tenant) metricsMiddleware() client2.RTMiddleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return client2.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			t.lastUsed.Store(start.UnixNano())
+			resp, err := next.RoundTrip(req)
+			t.requests.Add(1)
+			t.latency.Add(int64(time.Since(start)))
+			if err != nil || resp.StatusCode >= 400 {
+				t.errors.Add(1)
+			}
+			return resp, err
+		})
+	}
+}
+
+func (t *tenant) metrics() TenantMetrics {
+	m := TenantMetrics{
+		TenantID:     t.id,
+		Requests:     t.requests.Load(),
+		Errors:       t.errors.Load(),
+		TotalLatency: time.Duration(t.latency.Load()),
+		Created:      t.created,
+		LastUsed:     time.Unix(0, t.lastUsed.Load()),
+	}
+	if c := t.api.AuthCounters(); c != nil {
+		m.Refreshes = c.Refreshes()
+		m.ResignedRetries = c.ResignedRetries()
+	}
+	return m
+}
+
+// TenantID is a short fingerprint of an installation token, safe for metrics, spans and logs.
+func TenantID(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
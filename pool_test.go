@@ -0,0 +1,81 @@
+package lw_api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newPoolTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/Auth/AuthorizeByApplication" {
+			fmt.Fprint(w, `{"Token":"00000000-0000-0000-0000-000000000001","TTL":1800}`)
+			return
+		}
+		fmt.Fprint(w, `[]`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClientPool(t *testing.T) {
+	srv := newPoolTestServer(t)
+	newPool := func(t *testing.T, maxSize int) *ClientPool {
+		pool, err := NewClientPoolBuilder().
+			AppID("test-app-id").
+			AppSecret("test-app-secret").
+			MaxSize(maxSize).
+			Configure(func(token string, b *LinnworksAPIBuilder) {
+				b.BaseURL(srv.URL).AuthURL(srv.URL)
+			}).
+			Build()
+		if err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		t.Cleanup(func() { pool.Close() })
+		return pool
+	}
+
+	t.Run("should reuse the client for the same token", func(t *testing.T) {
+		pool := newPool(t, 0)
+		a, _ := pool.Get("tenant-a")
+		b, _ := pool.Get("tenant-a")
+		c, _ := pool.Get("tenant-b")
+		if a != b || a == c {
+			t.Error("Expected one client per token")
+		}
+	})
+
+	t.Run("should evict the least recently used client when full", func(t *testing.T) {
+		pool := newPool(t, 2)
+		a, _ := pool.Get("tenant-a")
+		pool.Get("tenant-b")
+		pool.Get("tenant-a")
+		pool.Get("tenant-c")
+		if pool.Len() != 2 {
+			t.Fatalf("Expected 2 clients, got %d", pool.Len())
+		}
+		again, _ := pool.Get("tenant-a")
+		if again != a {
+			t.Error("Expected tenant-a to survive eviction")
+		}
+	})
+
+	t.Run("should collect per-tenant metrics", func(t *testing.T) {
+		pool := newPool(t, 0)
+		api, _ := pool.Get("tenant-a")
+		if _, err := api.GetStockLocations(context.Background()).Do(); err != nil {
+			t.Fatalf("Expected nil error, got %v", err)
+		}
+		metrics := pool.Metrics()
+		if len(metrics) != 1 || metrics[0].Requests != 1 || metrics[0].Errors != 0 {
+			t.Errorf("Expected one successful request, got %+v", metrics)
+		}
+		if metrics[0].TenantID == "tenant-a" {
+			t.Error("Expected tenant id to not expose the token")
+		}
+	})
+}
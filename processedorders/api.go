@@ -0,0 +1,10 @@
+package processedorders
+
+import "context"
+
+// ProcessedOrdersAPI is the processed orders branch of the client.
+type ProcessedOrdersAPI interface {
+	SearchProcessedOrders(ctx context.Context) *SearchProcessedOrdersRequestBuilder
+}
+
+var _ ProcessedOrdersAPI = (*ProcessedOrders)(nil)
@@ -9,6 +9,7 @@ import (
 	"github.com/go-openapi/strfmt"
 
 	lw_api "github.com/MMC-BK/lw-api/client"
+	"github.com/MMC-BK/lw-api/paging"
 	processedmodels "github.com/MMC-BK/lw-api/processedorders/models"
 )
 
@@ -24,6 +25,7 @@ type SearchProcessedOrdersRequestBuilder struct {
 	payload *processedmodels.ProcessedOrdersSearchProcessedOrdersRequest
 	request *processedmodels.SearchProcessedOrdersRequest
 	err     []error
+	opts    []lw_api.CallOption
 }
 
 func (o ProcessedOrders) SearchProcessedOrders(ctx context.Context) *SearchProcessedOrdersRequestBuilder {
@@ -119,6 +121,24 @@ func (b *SearchProcessedOrdersRequestBuilder) searchSorting(sortDirection, sortF
 	return b
 }
 
+// RetryPolicy overrides the client retry policy for this call.
+func (b *SearchProcessedOrdersRequestBuilder) RetryPolicy(policy lw_api.RetryPolicy) *SearchProcessedOrdersRequestBuilder {
+	return b.WithOptions(lw_api.CallRetryPolicy(policy))
+}
+
+// WithOptions applies per-call options such as a timeout, extra headers or a rate limit priority.
+func (b *SearchProcessedOrdersRequestBuilder) WithOptions(opts ...lw_api.CallOption) *SearchProcessedOrdersRequestBuilder {
+	if b == nil {
+		return nil
+	}
+	b.opts = append(b.opts, opts...)
+	return b
+}
+
+func (b *SearchProcessedOrdersRequestBuilder) requestContext(ctx context.Context) context.Context {
+	return lw_api.WithCallOptions(ctx, b.opts...)
+}
+
 func (b *SearchProcessedOrdersRequestBuilder) build() (*processedmodels.ProcessedOrdersSearchProcessedOrdersRequest, error) {
 	if b == nil {
 		return nil, errors.New("builder is nil")
@@ -129,6 +149,8 @@ func (b *SearchProcessedOrdersRequestBuilder) build() (*processedmodels.Processe
 		errs = append(errs, errors.New("payload is required"))
 	} else if b.payload.Request == nil {
 		errs = append(errs, errors.New("request body is required"))
+	} else {
+		errs = append(errs, lw_api.ValidatePayload(b.ctx, b.payload)...)
 	}
 	if len(errs) > 0 {
 		return nil, errors.Join(errs...)
@@ -140,12 +162,46 @@ func (b *SearchProcessedOrdersRequestBuilder) Do() (*processedmodels.GenericPage
 	if b == nil {
 		return nil, errors.New("builder is nil")
 	}
+	return b.do(b.ctx)
+}
+
+// DoRaw sends the request and returns the undecoded response with its status and headers.
+func (b *SearchProcessedOrdersRequestBuilder) DoRaw() (*lw_api.Response, error) {
+	if b == nil {
+		return nil, errors.New("builder is nil")
+	}
+	req, err := b.build()
+	if err != nil {
+		return nil, err
+	}
+	return lw_api.DoRaw(b.requestContext(b.ctx), b.client, http.MethodPost, "/api/ProcessedOrders/SearchProcessedOrders", nil, req)
+}
+
+// Pager walks all result pages starting from the configured page number.
+func (b *SearchProcessedOrdersRequestBuilder) Pager(opts ...paging.Option) *paging.Pager[*processedmodels.ProcessedOrderWeb] {
+	if b == nil {
+		return nil
+	}
+	if b.request.PageNumber > 0 {
+		opts = append([]paging.Option{paging.StartPage(b.request.PageNumber)}, opts...)
+	}
+	return paging.New(func(ctx context.Context, page int32) (paging.Page[*processedmodels.ProcessedOrderWeb], error) {
+		b.request.PageNumber = page
+		out, err := b.do(ctx)
+		if err != nil {
+			return paging.Page[*processedmodels.ProcessedOrderWeb]{}, err
+		}
+		return paging.FromProcessedOrderWeb(out), nil
+	}, opts...)
+}
+
+func (b *SearchProcessedOrdersRequestBuilder) do(ctx context.Context) (*processedmodels.GenericPagedResultProcessedOrderWeb, error) {
 	req, err := b.build()
 	if err != nil {
 		return nil, err
 	}
 	var out processedmodels.SearchProcessedOrdersResponse
-	if err := b.client.DoJSON(b.ctx, http.MethodPost, "/api/ProcessedOrders/SearchProcessedOrders", nil, req, &out); err != nil {
+	if err := b.client.DoJSON(b.requestContext(ctx), http.MethodPost, "/api/ProcessedOrders/SearchProcessedOrders", nil, req, &out); err != nil {
 		return nil, err
 	}
 	return out.ProcessedOrders, nil
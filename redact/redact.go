@@ -0,0 +1,173 @@
+// Package redact masks credentials and customer PII in headers and payloads before they reach logs or errors.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const Mask = "[REDACTED]"
+
+// MaxBody is the default number of bytes kept by Body.
+const MaxBody = 2 << 10
+
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// secretKeys are lower-cased JSON keys holding credentials.
+var secretKeys = map[string]bool{
+	"authorization":     true,
+	"applicationsecret": true,
+	"token":             true,
+	"accesstoken":       true,
+	"refreshtoken":      true,
+	"password":          true,
+	"secret":            true,
+	"databasepassword":  true,
+}
+
+// piiKeys are lower-cased JSON keys holding customer contact and address data of Linnworks orders.
+var piiKeys = map[string]bool{
+	"email":            true,
+	"emailaddress":     true,
+	"phone":            true,
+	"phonenumber":      true,
+	"address":          true,
+	"address1":         true,
+	"address2":         true,
+	"address3":         true,
+	"fullname":         true,
+	"town":             true,
+	"region":           true,
+	"postcode":         true,
+	"company":          true,
+	"buyername":        true,
+	"channelbuyername": true,
+}
+
+// IsSecretKey reports whether the JSON key holds a credential.
+func IsSecretKey(key string) bool {
+	return secretKeys[strings.ToLower(key)]
+}
+
+// IsSensitiveKey reports whether values under the JSON key are masked by JSON: credentials or PII.
+func IsSensitiveKey(key string) bool {
+	k := strings.ToLower(key)
+	return secretKeys[k] || piiKeys[k]
+}
+
+// Headers returns a copy of h with credential headers masked.
+func Headers(h http.Header) http.Header {
+	out := h.Clone()
+	for k := range out {
+		if sensitiveHeaders[http.CanonicalHeaderKey(k)] {
+			out[k] = []string{Mask}
+		}
+	}
+	return out
+}
+
+// JSON masks credentials and PII at any depth. Input that is not JSON falls back to pattern masking.
+func JSON(body []byte) []byte {
+	return jsonKeys(body, IsSensitiveKey, keyValuePattern)
+}
+
+// Secrets masks only credentials, keeping order and customer data intact (e.g. for test fixtures).
+func Secrets(body []byte) []byte {
+	return jsonKeys(body, IsSecretKey, secretKeyValuePattern)
+}
+
+func jsonKeys(body []byte, sensitive func(string) bool, fallback *regexp.Regexp) []byte {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return trimmed
+	}
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return maskPairs(trimmed, fallback)
+	}
+	out, err := json.Marshal(walk(v, sensitive))
+	if err != nil {
+		return maskPairs(trimmed, fallback)
+	}
+	return out
+}
+
+// Body redacts body and truncates the result to MaxBody bytes, for error messages and logs.
+func Body(body []byte) string {
+	return Truncate(string(JSON(body)), MaxBody)
+}
+
+var (
+	keyValuePattern       = keyValueRegexp(secretKeys, piiKeys)
+	secretKeyValuePattern = keyValueRegexp(secretKeys)
+)
+
+// Text masks `key: value`, `"key":"value"` and `key=value` pairs for sensitive keys in free text.
+func Text(body []byte) []byte {
+	return maskPairs(body, keyValuePattern)
+}
+
+func maskPairs(body []byte, pattern *regexp.Regexp) []byte {
+	return pattern.ReplaceAll(body, []byte(`${1}"`+Mask+`"`))
+}
+
+// Truncate cuts s to max bytes, marking how much was dropped.
+func Truncate(s string, max int) string {
+	if max <= 0 || len(s) <= max {
+		return s
+	}
+	return fmt.Sprintf("%s…(%d more bytes)", s[:max], len(s)-max)
+}
+
+func walk(v any, sensitive func(string) bool) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			switch val.(type) {
+			case map[string]any, []any:
+				// e.g. CustomerInfo.Address is an object: mask its fields, not the whole subtree
+				t[k] = walk(val, sensitive)
+			case nil:
+			default:
+				if sensitive(k) {
+					t[k] = Mask
+				}
+			}
+		}
+		return t
+	case []any:
+		for i := range t {
+			t[i] = walk(t[i], sensitive)
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+func keyValueRegexp(sets ...map[string]bool) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)("?\b(` + keyAlternation(sets) + `)"?\s*[:=]\s*)("[^"]*"|[^\s,&}]+)`)
+}
+
+func keyAlternation(sets []map[string]bool) string {
+	var keys []string
+	for _, set := range sets {
+		for k := range set {
+			keys = append(keys, regexp.QuoteMeta(k))
+		}
+	}
+	// longest first so that address1 is not matched as address
+	sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+	return strings.Join(keys, "|")
+}
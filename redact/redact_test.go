@@ -0,0 +1,55 @@
+package redact
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	in := `{"applicationSecret":"s3cr3t","token":"abc","OrderId":"keep","CustomerInfo":{"Address":{"EmailAddress":"a@b.c","PhoneNumber":"123","Address1":"1 Road","Town":"Leeds","Region":"West Yorkshire","Country":"UK"}}}`
+	out := string(JSON([]byte(in)))
+	for _, leaked := range []string{"s3cr3t", "abc", "a@b.c", "123", "1 Road", "Leeds", "West Yorkshire"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("Expected %q to be redacted, got %s", leaked, out)
+		}
+	}
+	for _, kept := range []string{"keep", "UK"} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("Expected %q to be kept, got %s", kept, out)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	out := string(Text([]byte(`bad request token=abc&applicationSecret="s3cr3t" pkOrderToken=keep`)))
+	if strings.Contains(out, "abc") || strings.Contains(out, "s3cr3t") {
+		t.Errorf("Expected secrets to be redacted, got %s", out)
+	}
+	if !strings.Contains(out, "keep") {
+		t.Errorf("Expected unrelated keys to be kept, got %s", out)
+	}
+}
+
+func TestSecrets(t *testing.T) {
+	out := string(Secrets([]byte(`token=abc&Town=Leeds&EmailAddress=a@b.c`)))
+	if strings.Contains(out, "abc") {
+		t.Errorf("Expected token to be redacted, got %s", out)
+	}
+	for _, kept := range []string{"Leeds", "a@b.c"} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("Expected %q to be kept in a non-JSON body, got %s", kept, out)
+		}
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	h := http.Header{"Authorization": {"token"}, "Accept": {"application/json"}}
+	out := Headers(h)
+	if out.Get("Authorization") != Mask || out.Get("Accept") != "application/json" {
+		t.Errorf("Unexpected headers %v", out)
+	}
+	if h.Get("Authorization") != "token" {
+		t.Error("Expected the original headers to be untouched")
+	}
+}